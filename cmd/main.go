@@ -5,11 +5,14 @@ import (
 	"google.golang.org/grpc"
 	"log"
 	"net"
+	"ryg-task-service/clock"
 	"ryg-task-service/conf"
 	"ryg-task-service/db"
 	pb "ryg-task-service/gen_proto/task_service"
 	"ryg-task-service/rabbit_mq"
+	"ryg-task-service/scheduler"
 	"ryg-task-service/service"
+	_ "time/tzdata"
 )
 
 func main() {
@@ -27,11 +30,21 @@ func main() {
 
 	grpcServer := grpc.NewServer()
 
-	taskService := service.NewTaskService(db.DB)
-	challengeService := service.NewChallengeService(db.DB, publisherManager.GenericEmailQueuePublisher)
+	clk := clock.NewSystemClock()
+
+	taskService := service.NewTaskService(db.DB, clk)
+	challengeService := service.NewChallengeService(db.DB, publisherManager.GenericEmailQueuePublisher, clk, cnf.ChallengeConfig)
 	taskService.ChallengeSvs = challengeService
 	challengeService.TaskSvs = taskService
 
+	challengeScheduler := scheduler.NewScheduler(cnf.SchedulerConfig,
+		scheduler.Job{Name: "start-scheduled-challenges", Run: challengeService.StartScheduledChallenges},
+		scheduler.Job{Name: "settle-elapsed-weeks", Run: challengeService.SettleElapsedWeeks},
+		scheduler.Job{Name: "finish-expired-challenges", Run: challengeService.FinishExpiredChallenges},
+	)
+	challengeScheduler.Start()
+	defer challengeScheduler.Stop()
+
 	pb.RegisterTaskServiceServer(grpcServer, taskService)
 	pb.RegisterChallengeServiceServer(grpcServer, challengeService)
 
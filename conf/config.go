@@ -1,9 +1,17 @@
 package conf
 
 import (
+	"log"
 	"os"
+	"strconv"
+	"time"
 )
 
+const defaultMaxChallengeDays = 7
+
+// defaultGraceDays lets participants update their statuses of yesterday.
+const defaultGraceDays = 1
+
 type DBConfig struct {
 	DBHost     string
 	DBPort     string
@@ -21,9 +29,20 @@ type RabbitMQConfig struct {
 	Password string
 }
 
+type SchedulerConfig struct {
+	Interval time.Duration
+}
+
+type ChallengeConfig struct {
+	MaxDays   int32
+	GraceDays int32
+}
+
 type Config struct {
 	DB                DBConfig
 	RabbitMQConfig    RabbitMQConfig
+	SchedulerConfig   SchedulerConfig
+	ChallengeConfig   ChallengeConfig
 	RYGTaskServiceUrl string
 }
 
@@ -44,6 +63,52 @@ func LoadConfig() *Config {
 			User:     os.Getenv("RABBITMQ_USER"),
 			Password: os.Getenv("RABBITMQ_PASSWORD"),
 		},
+		SchedulerConfig: SchedulerConfig{
+			Interval: getEnvDuration("SCHEDULER_INTERVAL", time.Minute),
+		},
+		ChallengeConfig: ChallengeConfig{
+			MaxDays:   getEnvInt32("CHALLENGE_MAX_DAYS", defaultMaxChallengeDays, 1),
+			GraceDays: getEnvInt32("CHALLENGE_GRACE_DAYS", defaultGraceDays, 0),
+		},
 		RYGTaskServiceUrl: os.Getenv("RYG_TASK_SERVICE_URL"),
 	}
 }
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("Invalid duration for %s: %v", key, err)
+	}
+
+	if duration <= 0 {
+		log.Printf("Duration for %s must be positive, using %v", key, defaultValue)
+		return defaultValue
+	}
+
+	return duration
+}
+
+// getEnvInt32 reads a number, falling back to the default when it is below min.
+func getEnvInt32(key string, defaultValue, min int32) int32 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	number, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		log.Fatalf("Invalid number for %s: %v", key, err)
+	}
+
+	if int32(number) < min {
+		log.Printf("Number for %s must be at least %d, using %d", key, min, defaultValue)
+		return defaultValue
+	}
+
+	return int32(number)
+}
@@ -34,12 +34,27 @@ func ConnectDB(cnf conf.DBConfig) {
 	DB = db
 	fmt.Println("Connected to the database")
 
-	if err := DB.AutoMigrate(allTables()...); err != nil {
+	if err := Migrate(DB); err != nil {
 		log.Fatalf("Error migrating database: %v", err)
 	}
 	fmt.Println("Database migrated")
 }
 
+// Migrate brings the schema of the database up to date with the models.
+func Migrate(db *gorm.DB) error {
+	// AutoMigrate only creates missing check constraints, so the ones whose allowed values
+	// have changed are dropped first and recreated from the current model definitions.
+	for _, c := range refreshedConstraints() {
+		if db.Migrator().HasConstraint(c.table, c.name) {
+			if err := db.Migrator().DropConstraint(c.table, c.name); err != nil {
+				return fmt.Errorf("drop constraint %s: %w", c.name, err)
+			}
+		}
+	}
+
+	return db.AutoMigrate(allTables()...)
+}
+
 func allTables() []interface{} {
 	return []interface{}{
 		&model.Challenge{},
@@ -47,6 +62,25 @@ func allTables() []interface{} {
 		&model.TaskAndStatus{},
 		&model.ChallengeAndUser{},
 		&model.ChallengeInvitation{},
+		&model.ChallengeTemplate{},
+		&model.ChallengeTemplateTask{},
+		&model.ChecklistItem{},
+		&model.ChecklistItemState{},
+		&model.TaskStatusChange{},
+		&model.StatusCorrection{},
+		&model.Streak{},
+	}
+}
+
+type constraint struct {
+	table interface{}
+	name  string
+}
+
+func refreshedConstraints() []constraint {
+	return []constraint{
+		{&model.Challenge{}, "chk_challenges_status"},
+		{&model.TaskAndStatus{}, "chk_task_and_status_status"},
 	}
 }
 
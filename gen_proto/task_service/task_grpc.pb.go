@@ -20,16 +20,27 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ChallengeService_GetChallengeById_FullMethodName         = "/task_microservice.ChallengeService/GetChallengeById"
-	ChallengeService_GetChallengesByUserId_FullMethodName    = "/task_microservice.ChallengeService/GetChallengesByUserId"
-	ChallengeService_CreateChallenge_FullMethodName          = "/task_microservice.ChallengeService/CreateChallenge"
-	ChallengeService_UpdateChallenge_FullMethodName          = "/task_microservice.ChallengeService/UpdateChallenge"
-	ChallengeService_DeleteChallenge_FullMethodName          = "/task_microservice.ChallengeService/DeleteChallenge"
-	ChallengeService_StartChallenge_FullMethodName           = "/task_microservice.ChallengeService/StartChallenge"
-	ChallengeService_FinishChallenge_FullMethodName          = "/task_microservice.ChallengeService/FinishChallenge"
-	ChallengeService_AddUserToChallenge_FullMethodName       = "/task_microservice.ChallengeService/AddUserToChallenge"
-	ChallengeService_SubscribeToChallenge_FullMethodName     = "/task_microservice.ChallengeService/SubscribeToChallenge"
-	ChallengeService_UnsubscribeFromChallenge_FullMethodName = "/task_microservice.ChallengeService/UnsubscribeFromChallenge"
+	ChallengeService_GetChallengeById_FullMethodName            = "/task_microservice.ChallengeService/GetChallengeById"
+	ChallengeService_GetChallengesByUserId_FullMethodName       = "/task_microservice.ChallengeService/GetChallengesByUserId"
+	ChallengeService_CreateChallenge_FullMethodName             = "/task_microservice.ChallengeService/CreateChallenge"
+	ChallengeService_UpdateChallenge_FullMethodName             = "/task_microservice.ChallengeService/UpdateChallenge"
+	ChallengeService_DeleteChallenge_FullMethodName             = "/task_microservice.ChallengeService/DeleteChallenge"
+	ChallengeService_StartChallenge_FullMethodName              = "/task_microservice.ChallengeService/StartChallenge"
+	ChallengeService_ScheduleChallenge_FullMethodName           = "/task_microservice.ChallengeService/ScheduleChallenge"
+	ChallengeService_FinishChallenge_FullMethodName             = "/task_microservice.ChallengeService/FinishChallenge"
+	ChallengeService_PauseChallenge_FullMethodName              = "/task_microservice.ChallengeService/PauseChallenge"
+	ChallengeService_ResumeChallenge_FullMethodName             = "/task_microservice.ChallengeService/ResumeChallenge"
+	ChallengeService_AddUserToChallenge_FullMethodName          = "/task_microservice.ChallengeService/AddUserToChallenge"
+	ChallengeService_SubscribeToChallenge_FullMethodName        = "/task_microservice.ChallengeService/SubscribeToChallenge"
+	ChallengeService_UnsubscribeFromChallenge_FullMethodName    = "/task_microservice.ChallengeService/UnsubscribeFromChallenge"
+	ChallengeService_UpdateParticipantTimeZone_FullMethodName   = "/task_microservice.ChallengeService/UpdateParticipantTimeZone"
+	ChallengeService_SaveChallengeAsTemplate_FullMethodName     = "/task_microservice.ChallengeService/SaveChallengeAsTemplate"
+	ChallengeService_GetTemplatesByUserId_FullMethodName        = "/task_microservice.ChallengeService/GetTemplatesByUserId"
+	ChallengeService_CreateChallengeFromTemplate_FullMethodName = "/task_microservice.ChallengeService/CreateChallengeFromTemplate"
+	ChallengeService_CloneChallenge_FullMethodName              = "/task_microservice.ChallengeService/CloneChallenge"
+	ChallengeService_SetChallengeRecurrence_FullMethodName      = "/task_microservice.ChallengeService/SetChallengeRecurrence"
+	ChallengeService_SetChallengeGracePeriod_FullMethodName     = "/task_microservice.ChallengeService/SetChallengeGracePeriod"
+	ChallengeService_GetChallengeSeries_FullMethodName          = "/task_microservice.ChallengeService/GetChallengeSeries"
 )
 
 // ChallengeServiceClient is the client API for ChallengeService service.
@@ -42,10 +53,21 @@ type ChallengeServiceClient interface {
 	UpdateChallenge(ctx context.Context, in *UpdateChallengeRequest, opts ...grpc.CallOption) (*Challenge, error)
 	DeleteChallenge(ctx context.Context, in *DeleteChallengeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	StartChallenge(ctx context.Context, in *StartChallengeRequest, opts ...grpc.CallOption) (*Challenge, error)
+	ScheduleChallenge(ctx context.Context, in *ScheduleChallengeRequest, opts ...grpc.CallOption) (*Challenge, error)
 	FinishChallenge(ctx context.Context, in *FinishChallengeRequest, opts ...grpc.CallOption) (*Challenge, error)
+	PauseChallenge(ctx context.Context, in *PauseChallengeRequest, opts ...grpc.CallOption) (*Challenge, error)
+	ResumeChallenge(ctx context.Context, in *ResumeChallengeRequest, opts ...grpc.CallOption) (*Challenge, error)
 	AddUserToChallenge(ctx context.Context, in *AddUserToChallengeRequest, opts ...grpc.CallOption) (*AddUserToChallengeResponse, error)
 	SubscribeToChallenge(ctx context.Context, in *SubscribeToChallengeRequest, opts ...grpc.CallOption) (*Challenge, error)
 	UnsubscribeFromChallenge(ctx context.Context, in *UnsubscribeFromChallengeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateParticipantTimeZone(ctx context.Context, in *UpdateParticipantTimeZoneRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SaveChallengeAsTemplate(ctx context.Context, in *SaveChallengeAsTemplateRequest, opts ...grpc.CallOption) (*ChallengeTemplate, error)
+	GetTemplatesByUserId(ctx context.Context, in *GetTemplatesRequest, opts ...grpc.CallOption) (*ChallengeTemplateList, error)
+	CreateChallengeFromTemplate(ctx context.Context, in *CreateChallengeFromTemplateRequest, opts ...grpc.CallOption) (*Challenge, error)
+	CloneChallenge(ctx context.Context, in *CloneChallengeRequest, opts ...grpc.CallOption) (*Challenge, error)
+	SetChallengeRecurrence(ctx context.Context, in *SetChallengeRecurrenceRequest, opts ...grpc.CallOption) (*Challenge, error)
+	SetChallengeGracePeriod(ctx context.Context, in *SetChallengeGracePeriodRequest, opts ...grpc.CallOption) (*Challenge, error)
+	GetChallengeSeries(ctx context.Context, in *GetChallengeSeriesRequest, opts ...grpc.CallOption) (*ChallengeList, error)
 }
 
 type challengeServiceClient struct {
@@ -116,6 +138,16 @@ func (c *challengeServiceClient) StartChallenge(ctx context.Context, in *StartCh
 	return out, nil
 }
 
+func (c *challengeServiceClient) ScheduleChallenge(ctx context.Context, in *ScheduleChallengeRequest, opts ...grpc.CallOption) (*Challenge, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Challenge)
+	err := c.cc.Invoke(ctx, ChallengeService_ScheduleChallenge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *challengeServiceClient) FinishChallenge(ctx context.Context, in *FinishChallengeRequest, opts ...grpc.CallOption) (*Challenge, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Challenge)
@@ -126,6 +158,26 @@ func (c *challengeServiceClient) FinishChallenge(ctx context.Context, in *Finish
 	return out, nil
 }
 
+func (c *challengeServiceClient) PauseChallenge(ctx context.Context, in *PauseChallengeRequest, opts ...grpc.CallOption) (*Challenge, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Challenge)
+	err := c.cc.Invoke(ctx, ChallengeService_PauseChallenge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *challengeServiceClient) ResumeChallenge(ctx context.Context, in *ResumeChallengeRequest, opts ...grpc.CallOption) (*Challenge, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Challenge)
+	err := c.cc.Invoke(ctx, ChallengeService_ResumeChallenge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *challengeServiceClient) AddUserToChallenge(ctx context.Context, in *AddUserToChallengeRequest, opts ...grpc.CallOption) (*AddUserToChallengeResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddUserToChallengeResponse)
@@ -156,6 +208,86 @@ func (c *challengeServiceClient) UnsubscribeFromChallenge(ctx context.Context, i
 	return out, nil
 }
 
+func (c *challengeServiceClient) UpdateParticipantTimeZone(ctx context.Context, in *UpdateParticipantTimeZoneRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChallengeService_UpdateParticipantTimeZone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *challengeServiceClient) SaveChallengeAsTemplate(ctx context.Context, in *SaveChallengeAsTemplateRequest, opts ...grpc.CallOption) (*ChallengeTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChallengeTemplate)
+	err := c.cc.Invoke(ctx, ChallengeService_SaveChallengeAsTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *challengeServiceClient) GetTemplatesByUserId(ctx context.Context, in *GetTemplatesRequest, opts ...grpc.CallOption) (*ChallengeTemplateList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChallengeTemplateList)
+	err := c.cc.Invoke(ctx, ChallengeService_GetTemplatesByUserId_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *challengeServiceClient) CreateChallengeFromTemplate(ctx context.Context, in *CreateChallengeFromTemplateRequest, opts ...grpc.CallOption) (*Challenge, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Challenge)
+	err := c.cc.Invoke(ctx, ChallengeService_CreateChallengeFromTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *challengeServiceClient) CloneChallenge(ctx context.Context, in *CloneChallengeRequest, opts ...grpc.CallOption) (*Challenge, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Challenge)
+	err := c.cc.Invoke(ctx, ChallengeService_CloneChallenge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *challengeServiceClient) SetChallengeRecurrence(ctx context.Context, in *SetChallengeRecurrenceRequest, opts ...grpc.CallOption) (*Challenge, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Challenge)
+	err := c.cc.Invoke(ctx, ChallengeService_SetChallengeRecurrence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *challengeServiceClient) SetChallengeGracePeriod(ctx context.Context, in *SetChallengeGracePeriodRequest, opts ...grpc.CallOption) (*Challenge, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Challenge)
+	err := c.cc.Invoke(ctx, ChallengeService_SetChallengeGracePeriod_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *challengeServiceClient) GetChallengeSeries(ctx context.Context, in *GetChallengeSeriesRequest, opts ...grpc.CallOption) (*ChallengeList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChallengeList)
+	err := c.cc.Invoke(ctx, ChallengeService_GetChallengeSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChallengeServiceServer is the server API for ChallengeService service.
 // All implementations must embed UnimplementedChallengeServiceServer
 // for forward compatibility.
@@ -166,10 +298,21 @@ type ChallengeServiceServer interface {
 	UpdateChallenge(context.Context, *UpdateChallengeRequest) (*Challenge, error)
 	DeleteChallenge(context.Context, *DeleteChallengeRequest) (*emptypb.Empty, error)
 	StartChallenge(context.Context, *StartChallengeRequest) (*Challenge, error)
+	ScheduleChallenge(context.Context, *ScheduleChallengeRequest) (*Challenge, error)
 	FinishChallenge(context.Context, *FinishChallengeRequest) (*Challenge, error)
+	PauseChallenge(context.Context, *PauseChallengeRequest) (*Challenge, error)
+	ResumeChallenge(context.Context, *ResumeChallengeRequest) (*Challenge, error)
 	AddUserToChallenge(context.Context, *AddUserToChallengeRequest) (*AddUserToChallengeResponse, error)
 	SubscribeToChallenge(context.Context, *SubscribeToChallengeRequest) (*Challenge, error)
 	UnsubscribeFromChallenge(context.Context, *UnsubscribeFromChallengeRequest) (*emptypb.Empty, error)
+	UpdateParticipantTimeZone(context.Context, *UpdateParticipantTimeZoneRequest) (*emptypb.Empty, error)
+	SaveChallengeAsTemplate(context.Context, *SaveChallengeAsTemplateRequest) (*ChallengeTemplate, error)
+	GetTemplatesByUserId(context.Context, *GetTemplatesRequest) (*ChallengeTemplateList, error)
+	CreateChallengeFromTemplate(context.Context, *CreateChallengeFromTemplateRequest) (*Challenge, error)
+	CloneChallenge(context.Context, *CloneChallengeRequest) (*Challenge, error)
+	SetChallengeRecurrence(context.Context, *SetChallengeRecurrenceRequest) (*Challenge, error)
+	SetChallengeGracePeriod(context.Context, *SetChallengeGracePeriodRequest) (*Challenge, error)
+	GetChallengeSeries(context.Context, *GetChallengeSeriesRequest) (*ChallengeList, error)
 	mustEmbedUnimplementedChallengeServiceServer()
 }
 
@@ -198,9 +341,18 @@ func (UnimplementedChallengeServiceServer) DeleteChallenge(context.Context, *Del
 func (UnimplementedChallengeServiceServer) StartChallenge(context.Context, *StartChallengeRequest) (*Challenge, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartChallenge not implemented")
 }
+func (UnimplementedChallengeServiceServer) ScheduleChallenge(context.Context, *ScheduleChallengeRequest) (*Challenge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleChallenge not implemented")
+}
 func (UnimplementedChallengeServiceServer) FinishChallenge(context.Context, *FinishChallengeRequest) (*Challenge, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FinishChallenge not implemented")
 }
+func (UnimplementedChallengeServiceServer) PauseChallenge(context.Context, *PauseChallengeRequest) (*Challenge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseChallenge not implemented")
+}
+func (UnimplementedChallengeServiceServer) ResumeChallenge(context.Context, *ResumeChallengeRequest) (*Challenge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeChallenge not implemented")
+}
 func (UnimplementedChallengeServiceServer) AddUserToChallenge(context.Context, *AddUserToChallengeRequest) (*AddUserToChallengeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddUserToChallenge not implemented")
 }
@@ -210,6 +362,30 @@ func (UnimplementedChallengeServiceServer) SubscribeToChallenge(context.Context,
 func (UnimplementedChallengeServiceServer) UnsubscribeFromChallenge(context.Context, *UnsubscribeFromChallengeRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UnsubscribeFromChallenge not implemented")
 }
+func (UnimplementedChallengeServiceServer) UpdateParticipantTimeZone(context.Context, *UpdateParticipantTimeZoneRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateParticipantTimeZone not implemented")
+}
+func (UnimplementedChallengeServiceServer) SaveChallengeAsTemplate(context.Context, *SaveChallengeAsTemplateRequest) (*ChallengeTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveChallengeAsTemplate not implemented")
+}
+func (UnimplementedChallengeServiceServer) GetTemplatesByUserId(context.Context, *GetTemplatesRequest) (*ChallengeTemplateList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTemplatesByUserId not implemented")
+}
+func (UnimplementedChallengeServiceServer) CreateChallengeFromTemplate(context.Context, *CreateChallengeFromTemplateRequest) (*Challenge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateChallengeFromTemplate not implemented")
+}
+func (UnimplementedChallengeServiceServer) CloneChallenge(context.Context, *CloneChallengeRequest) (*Challenge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloneChallenge not implemented")
+}
+func (UnimplementedChallengeServiceServer) SetChallengeRecurrence(context.Context, *SetChallengeRecurrenceRequest) (*Challenge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetChallengeRecurrence not implemented")
+}
+func (UnimplementedChallengeServiceServer) SetChallengeGracePeriod(context.Context, *SetChallengeGracePeriodRequest) (*Challenge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetChallengeGracePeriod not implemented")
+}
+func (UnimplementedChallengeServiceServer) GetChallengeSeries(context.Context, *GetChallengeSeriesRequest) (*ChallengeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChallengeSeries not implemented")
+}
 func (UnimplementedChallengeServiceServer) mustEmbedUnimplementedChallengeServiceServer() {}
 func (UnimplementedChallengeServiceServer) testEmbeddedByValue()                          {}
 
@@ -339,6 +515,24 @@ func _ChallengeService_StartChallenge_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChallengeService_ScheduleChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChallengeServiceServer).ScheduleChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChallengeService_ScheduleChallenge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChallengeServiceServer).ScheduleChallenge(ctx, req.(*ScheduleChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ChallengeService_FinishChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FinishChallengeRequest)
 	if err := dec(in); err != nil {
@@ -357,6 +551,42 @@ func _ChallengeService_FinishChallenge_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChallengeService_PauseChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChallengeServiceServer).PauseChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChallengeService_PauseChallenge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChallengeServiceServer).PauseChallenge(ctx, req.(*PauseChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChallengeService_ResumeChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChallengeServiceServer).ResumeChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChallengeService_ResumeChallenge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChallengeServiceServer).ResumeChallenge(ctx, req.(*ResumeChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ChallengeService_AddUserToChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddUserToChallengeRequest)
 	if err := dec(in); err != nil {
@@ -411,6 +641,150 @@ func _ChallengeService_UnsubscribeFromChallenge_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChallengeService_UpdateParticipantTimeZone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateParticipantTimeZoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChallengeServiceServer).UpdateParticipantTimeZone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChallengeService_UpdateParticipantTimeZone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChallengeServiceServer).UpdateParticipantTimeZone(ctx, req.(*UpdateParticipantTimeZoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChallengeService_SaveChallengeAsTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveChallengeAsTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChallengeServiceServer).SaveChallengeAsTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChallengeService_SaveChallengeAsTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChallengeServiceServer).SaveChallengeAsTemplate(ctx, req.(*SaveChallengeAsTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChallengeService_GetTemplatesByUserId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChallengeServiceServer).GetTemplatesByUserId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChallengeService_GetTemplatesByUserId_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChallengeServiceServer).GetTemplatesByUserId(ctx, req.(*GetTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChallengeService_CreateChallengeFromTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateChallengeFromTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChallengeServiceServer).CreateChallengeFromTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChallengeService_CreateChallengeFromTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChallengeServiceServer).CreateChallengeFromTemplate(ctx, req.(*CreateChallengeFromTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChallengeService_CloneChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChallengeServiceServer).CloneChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChallengeService_CloneChallenge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChallengeServiceServer).CloneChallenge(ctx, req.(*CloneChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChallengeService_SetChallengeRecurrence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetChallengeRecurrenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChallengeServiceServer).SetChallengeRecurrence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChallengeService_SetChallengeRecurrence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChallengeServiceServer).SetChallengeRecurrence(ctx, req.(*SetChallengeRecurrenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChallengeService_SetChallengeGracePeriod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetChallengeGracePeriodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChallengeServiceServer).SetChallengeGracePeriod(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChallengeService_SetChallengeGracePeriod_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChallengeServiceServer).SetChallengeGracePeriod(ctx, req.(*SetChallengeGracePeriodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChallengeService_GetChallengeSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChallengeSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChallengeServiceServer).GetChallengeSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChallengeService_GetChallengeSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChallengeServiceServer).GetChallengeSeries(ctx, req.(*GetChallengeSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ChallengeService_ServiceDesc is the grpc.ServiceDesc for ChallengeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -442,10 +816,22 @@ var ChallengeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StartChallenge",
 			Handler:    _ChallengeService_StartChallenge_Handler,
 		},
+		{
+			MethodName: "ScheduleChallenge",
+			Handler:    _ChallengeService_ScheduleChallenge_Handler,
+		},
 		{
 			MethodName: "FinishChallenge",
 			Handler:    _ChallengeService_FinishChallenge_Handler,
 		},
+		{
+			MethodName: "PauseChallenge",
+			Handler:    _ChallengeService_PauseChallenge_Handler,
+		},
+		{
+			MethodName: "ResumeChallenge",
+			Handler:    _ChallengeService_ResumeChallenge_Handler,
+		},
 		{
 			MethodName: "AddUserToChallenge",
 			Handler:    _ChallengeService_AddUserToChallenge_Handler,
@@ -458,20 +844,69 @@ var ChallengeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UnsubscribeFromChallenge",
 			Handler:    _ChallengeService_UnsubscribeFromChallenge_Handler,
 		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "task.proto",
-}
-
-const (
-	TaskService_GetTasksByChallengeId_FullMethodName        = "/task_microservice.TaskService/GetTasksByChallengeId"
-	TaskService_GetTaskById_FullMethodName                  = "/task_microservice.TaskService/GetTaskById"
-	TaskService_GetTasksByChallengeIdAndDate_FullMethodName = "/task_microservice.TaskService/GetTasksByChallengeIdAndDate"
-	TaskService_CreateTasks_FullMethodName                  = "/task_microservice.TaskService/CreateTasks"
+		{
+			MethodName: "UpdateParticipantTimeZone",
+			Handler:    _ChallengeService_UpdateParticipantTimeZone_Handler,
+		},
+		{
+			MethodName: "SaveChallengeAsTemplate",
+			Handler:    _ChallengeService_SaveChallengeAsTemplate_Handler,
+		},
+		{
+			MethodName: "GetTemplatesByUserId",
+			Handler:    _ChallengeService_GetTemplatesByUserId_Handler,
+		},
+		{
+			MethodName: "CreateChallengeFromTemplate",
+			Handler:    _ChallengeService_CreateChallengeFromTemplate_Handler,
+		},
+		{
+			MethodName: "CloneChallenge",
+			Handler:    _ChallengeService_CloneChallenge_Handler,
+		},
+		{
+			MethodName: "SetChallengeRecurrence",
+			Handler:    _ChallengeService_SetChallengeRecurrence_Handler,
+		},
+		{
+			MethodName: "SetChallengeGracePeriod",
+			Handler:    _ChallengeService_SetChallengeGracePeriod_Handler,
+		},
+		{
+			MethodName: "GetChallengeSeries",
+			Handler:    _ChallengeService_GetChallengeSeries_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "task.proto",
+}
+
+const (
+	TaskService_GetTasksByChallengeId_FullMethodName        = "/task_microservice.TaskService/GetTasksByChallengeId"
+	TaskService_GetTaskById_FullMethodName                  = "/task_microservice.TaskService/GetTaskById"
+	TaskService_GetTasksByChallengeIdAndDate_FullMethodName = "/task_microservice.TaskService/GetTasksByChallengeIdAndDate"
+	TaskService_CreateTasks_FullMethodName                  = "/task_microservice.TaskService/CreateTasks"
 	TaskService_CreateTask_FullMethodName                   = "/task_microservice.TaskService/CreateTask"
 	TaskService_UpdateTask_FullMethodName                   = "/task_microservice.TaskService/UpdateTask"
 	TaskService_UpdateTaskStatus_FullMethodName             = "/task_microservice.TaskService/UpdateTaskStatus"
+	TaskService_UpdateTaskStatuses_FullMethodName           = "/task_microservice.TaskService/UpdateTaskStatuses"
+	TaskService_UpdateTaskProgress_FullMethodName           = "/task_microservice.TaskService/UpdateTaskProgress"
 	TaskService_DeleteTask_FullMethodName                   = "/task_microservice.TaskService/DeleteTask"
+	TaskService_RestoreTask_FullMethodName                  = "/task_microservice.TaskService/RestoreTask"
+	TaskService_ReorderTasks_FullMethodName                 = "/task_microservice.TaskService/ReorderTasks"
+	TaskService_GetTagCompletion_FullMethodName             = "/task_microservice.TaskService/GetTagCompletion"
+	TaskService_GetChallengeProgress_FullMethodName         = "/task_microservice.TaskService/GetChallengeProgress"
+	TaskService_GetStreaks_FullMethodName                   = "/task_microservice.TaskService/GetStreaks"
+	TaskService_GetTaskStatusHistory_FullMethodName         = "/task_microservice.TaskService/GetTaskStatusHistory"
+	TaskService_RequestStatusCorrection_FullMethodName      = "/task_microservice.TaskService/RequestStatusCorrection"
+	TaskService_ApproveStatusCorrection_FullMethodName      = "/task_microservice.TaskService/ApproveStatusCorrection"
+	TaskService_RejectStatusCorrection_FullMethodName       = "/task_microservice.TaskService/RejectStatusCorrection"
+	TaskService_GetStatusCorrections_FullMethodName         = "/task_microservice.TaskService/GetStatusCorrections"
+	TaskService_GetChecklistItems_FullMethodName            = "/task_microservice.TaskService/GetChecklistItems"
+	TaskService_AddChecklistItem_FullMethodName             = "/task_microservice.TaskService/AddChecklistItem"
+	TaskService_UpdateChecklistItem_FullMethodName          = "/task_microservice.TaskService/UpdateChecklistItem"
+	TaskService_DeleteChecklistItem_FullMethodName          = "/task_microservice.TaskService/DeleteChecklistItem"
+	TaskService_ToggleChecklistItem_FullMethodName          = "/task_microservice.TaskService/ToggleChecklistItem"
 )
 
 // TaskServiceClient is the client API for TaskService service.
@@ -485,7 +920,24 @@ type TaskServiceClient interface {
 	CreateTask(ctx context.Context, in *CreateTaskRequest, opts ...grpc.CallOption) (*Task, error)
 	UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*Task, error)
 	UpdateTaskStatus(ctx context.Context, in *UpdateTaskStatusRequest, opts ...grpc.CallOption) (*TaskWithStatus, error)
+	UpdateTaskStatuses(ctx context.Context, in *UpdateTaskStatusesRequest, opts ...grpc.CallOption) (*UpdateTaskStatusesResponse, error)
+	UpdateTaskProgress(ctx context.Context, in *UpdateTaskProgressRequest, opts ...grpc.CallOption) (*TaskWithStatus, error)
 	DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	ReorderTasks(ctx context.Context, in *ReorderTasksRequest, opts ...grpc.CallOption) (*TaskList, error)
+	GetTagCompletion(ctx context.Context, in *GetTagCompletionRequest, opts ...grpc.CallOption) (*TagCompletionList, error)
+	GetChallengeProgress(ctx context.Context, in *GetChallengeProgressRequest, opts ...grpc.CallOption) (*ChallengeProgress, error)
+	GetStreaks(ctx context.Context, in *GetStreaksRequest, opts ...grpc.CallOption) (*StreakList, error)
+	GetTaskStatusHistory(ctx context.Context, in *GetTaskStatusHistoryRequest, opts ...grpc.CallOption) (*TaskStatusChangeList, error)
+	RequestStatusCorrection(ctx context.Context, in *RequestStatusCorrectionRequest, opts ...grpc.CallOption) (*StatusCorrection, error)
+	ApproveStatusCorrection(ctx context.Context, in *ReviewStatusCorrectionRequest, opts ...grpc.CallOption) (*TaskWithStatus, error)
+	RejectStatusCorrection(ctx context.Context, in *ReviewStatusCorrectionRequest, opts ...grpc.CallOption) (*StatusCorrection, error)
+	GetStatusCorrections(ctx context.Context, in *GetStatusCorrectionsRequest, opts ...grpc.CallOption) (*StatusCorrectionList, error)
+	GetChecklistItems(ctx context.Context, in *GetChecklistItemsRequest, opts ...grpc.CallOption) (*ChecklistItemList, error)
+	AddChecklistItem(ctx context.Context, in *AddChecklistItemRequest, opts ...grpc.CallOption) (*ChecklistItem, error)
+	UpdateChecklistItem(ctx context.Context, in *UpdateChecklistItemRequest, opts ...grpc.CallOption) (*ChecklistItem, error)
+	DeleteChecklistItem(ctx context.Context, in *DeleteChecklistItemRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ToggleChecklistItem(ctx context.Context, in *ToggleChecklistItemRequest, opts ...grpc.CallOption) (*TaskWithStatus, error)
 }
 
 type taskServiceClient struct {
@@ -566,6 +1018,26 @@ func (c *taskServiceClient) UpdateTaskStatus(ctx context.Context, in *UpdateTask
 	return out, nil
 }
 
+func (c *taskServiceClient) UpdateTaskStatuses(ctx context.Context, in *UpdateTaskStatusesRequest, opts ...grpc.CallOption) (*UpdateTaskStatusesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateTaskStatusesResponse)
+	err := c.cc.Invoke(ctx, TaskService_UpdateTaskStatuses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) UpdateTaskProgress(ctx context.Context, in *UpdateTaskProgressRequest, opts ...grpc.CallOption) (*TaskWithStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskWithStatus)
+	err := c.cc.Invoke(ctx, TaskService_UpdateTaskProgress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *taskServiceClient) DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -576,6 +1048,156 @@ func (c *taskServiceClient) DeleteTask(ctx context.Context, in *DeleteTaskReques
 	return out, nil
 }
 
+func (c *taskServiceClient) RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, TaskService_RestoreTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) ReorderTasks(ctx context.Context, in *ReorderTasksRequest, opts ...grpc.CallOption) (*TaskList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskList)
+	err := c.cc.Invoke(ctx, TaskService_ReorderTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) GetTagCompletion(ctx context.Context, in *GetTagCompletionRequest, opts ...grpc.CallOption) (*TagCompletionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagCompletionList)
+	err := c.cc.Invoke(ctx, TaskService_GetTagCompletion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) GetChallengeProgress(ctx context.Context, in *GetChallengeProgressRequest, opts ...grpc.CallOption) (*ChallengeProgress, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChallengeProgress)
+	err := c.cc.Invoke(ctx, TaskService_GetChallengeProgress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) GetStreaks(ctx context.Context, in *GetStreaksRequest, opts ...grpc.CallOption) (*StreakList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StreakList)
+	err := c.cc.Invoke(ctx, TaskService_GetStreaks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) GetTaskStatusHistory(ctx context.Context, in *GetTaskStatusHistoryRequest, opts ...grpc.CallOption) (*TaskStatusChangeList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskStatusChangeList)
+	err := c.cc.Invoke(ctx, TaskService_GetTaskStatusHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) RequestStatusCorrection(ctx context.Context, in *RequestStatusCorrectionRequest, opts ...grpc.CallOption) (*StatusCorrection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusCorrection)
+	err := c.cc.Invoke(ctx, TaskService_RequestStatusCorrection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) ApproveStatusCorrection(ctx context.Context, in *ReviewStatusCorrectionRequest, opts ...grpc.CallOption) (*TaskWithStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskWithStatus)
+	err := c.cc.Invoke(ctx, TaskService_ApproveStatusCorrection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) RejectStatusCorrection(ctx context.Context, in *ReviewStatusCorrectionRequest, opts ...grpc.CallOption) (*StatusCorrection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusCorrection)
+	err := c.cc.Invoke(ctx, TaskService_RejectStatusCorrection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) GetStatusCorrections(ctx context.Context, in *GetStatusCorrectionsRequest, opts ...grpc.CallOption) (*StatusCorrectionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusCorrectionList)
+	err := c.cc.Invoke(ctx, TaskService_GetStatusCorrections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) GetChecklistItems(ctx context.Context, in *GetChecklistItemsRequest, opts ...grpc.CallOption) (*ChecklistItemList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChecklistItemList)
+	err := c.cc.Invoke(ctx, TaskService_GetChecklistItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) AddChecklistItem(ctx context.Context, in *AddChecklistItemRequest, opts ...grpc.CallOption) (*ChecklistItem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChecklistItem)
+	err := c.cc.Invoke(ctx, TaskService_AddChecklistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) UpdateChecklistItem(ctx context.Context, in *UpdateChecklistItemRequest, opts ...grpc.CallOption) (*ChecklistItem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChecklistItem)
+	err := c.cc.Invoke(ctx, TaskService_UpdateChecklistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) DeleteChecklistItem(ctx context.Context, in *DeleteChecklistItemRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TaskService_DeleteChecklistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) ToggleChecklistItem(ctx context.Context, in *ToggleChecklistItemRequest, opts ...grpc.CallOption) (*TaskWithStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskWithStatus)
+	err := c.cc.Invoke(ctx, TaskService_ToggleChecklistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TaskServiceServer is the server API for TaskService service.
 // All implementations must embed UnimplementedTaskServiceServer
 // for forward compatibility.
@@ -587,7 +1209,24 @@ type TaskServiceServer interface {
 	CreateTask(context.Context, *CreateTaskRequest) (*Task, error)
 	UpdateTask(context.Context, *UpdateTaskRequest) (*Task, error)
 	UpdateTaskStatus(context.Context, *UpdateTaskStatusRequest) (*TaskWithStatus, error)
+	UpdateTaskStatuses(context.Context, *UpdateTaskStatusesRequest) (*UpdateTaskStatusesResponse, error)
+	UpdateTaskProgress(context.Context, *UpdateTaskProgressRequest) (*TaskWithStatus, error)
 	DeleteTask(context.Context, *DeleteTaskRequest) (*emptypb.Empty, error)
+	RestoreTask(context.Context, *RestoreTaskRequest) (*Task, error)
+	ReorderTasks(context.Context, *ReorderTasksRequest) (*TaskList, error)
+	GetTagCompletion(context.Context, *GetTagCompletionRequest) (*TagCompletionList, error)
+	GetChallengeProgress(context.Context, *GetChallengeProgressRequest) (*ChallengeProgress, error)
+	GetStreaks(context.Context, *GetStreaksRequest) (*StreakList, error)
+	GetTaskStatusHistory(context.Context, *GetTaskStatusHistoryRequest) (*TaskStatusChangeList, error)
+	RequestStatusCorrection(context.Context, *RequestStatusCorrectionRequest) (*StatusCorrection, error)
+	ApproveStatusCorrection(context.Context, *ReviewStatusCorrectionRequest) (*TaskWithStatus, error)
+	RejectStatusCorrection(context.Context, *ReviewStatusCorrectionRequest) (*StatusCorrection, error)
+	GetStatusCorrections(context.Context, *GetStatusCorrectionsRequest) (*StatusCorrectionList, error)
+	GetChecklistItems(context.Context, *GetChecklistItemsRequest) (*ChecklistItemList, error)
+	AddChecklistItem(context.Context, *AddChecklistItemRequest) (*ChecklistItem, error)
+	UpdateChecklistItem(context.Context, *UpdateChecklistItemRequest) (*ChecklistItem, error)
+	DeleteChecklistItem(context.Context, *DeleteChecklistItemRequest) (*emptypb.Empty, error)
+	ToggleChecklistItem(context.Context, *ToggleChecklistItemRequest) (*TaskWithStatus, error)
 	mustEmbedUnimplementedTaskServiceServer()
 }
 
@@ -619,9 +1258,60 @@ func (UnimplementedTaskServiceServer) UpdateTask(context.Context, *UpdateTaskReq
 func (UnimplementedTaskServiceServer) UpdateTaskStatus(context.Context, *UpdateTaskStatusRequest) (*TaskWithStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateTaskStatus not implemented")
 }
+func (UnimplementedTaskServiceServer) UpdateTaskStatuses(context.Context, *UpdateTaskStatusesRequest) (*UpdateTaskStatusesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTaskStatuses not implemented")
+}
+func (UnimplementedTaskServiceServer) UpdateTaskProgress(context.Context, *UpdateTaskProgressRequest) (*TaskWithStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTaskProgress not implemented")
+}
 func (UnimplementedTaskServiceServer) DeleteTask(context.Context, *DeleteTaskRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTask not implemented")
 }
+func (UnimplementedTaskServiceServer) RestoreTask(context.Context, *RestoreTaskRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTask not implemented")
+}
+func (UnimplementedTaskServiceServer) ReorderTasks(context.Context, *ReorderTasksRequest) (*TaskList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderTasks not implemented")
+}
+func (UnimplementedTaskServiceServer) GetTagCompletion(context.Context, *GetTagCompletionRequest) (*TagCompletionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTagCompletion not implemented")
+}
+func (UnimplementedTaskServiceServer) GetChallengeProgress(context.Context, *GetChallengeProgressRequest) (*ChallengeProgress, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChallengeProgress not implemented")
+}
+func (UnimplementedTaskServiceServer) GetStreaks(context.Context, *GetStreaksRequest) (*StreakList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStreaks not implemented")
+}
+func (UnimplementedTaskServiceServer) GetTaskStatusHistory(context.Context, *GetTaskStatusHistoryRequest) (*TaskStatusChangeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskStatusHistory not implemented")
+}
+func (UnimplementedTaskServiceServer) RequestStatusCorrection(context.Context, *RequestStatusCorrectionRequest) (*StatusCorrection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestStatusCorrection not implemented")
+}
+func (UnimplementedTaskServiceServer) ApproveStatusCorrection(context.Context, *ReviewStatusCorrectionRequest) (*TaskWithStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveStatusCorrection not implemented")
+}
+func (UnimplementedTaskServiceServer) RejectStatusCorrection(context.Context, *ReviewStatusCorrectionRequest) (*StatusCorrection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectStatusCorrection not implemented")
+}
+func (UnimplementedTaskServiceServer) GetStatusCorrections(context.Context, *GetStatusCorrectionsRequest) (*StatusCorrectionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatusCorrections not implemented")
+}
+func (UnimplementedTaskServiceServer) GetChecklistItems(context.Context, *GetChecklistItemsRequest) (*ChecklistItemList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChecklistItems not implemented")
+}
+func (UnimplementedTaskServiceServer) AddChecklistItem(context.Context, *AddChecklistItemRequest) (*ChecklistItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddChecklistItem not implemented")
+}
+func (UnimplementedTaskServiceServer) UpdateChecklistItem(context.Context, *UpdateChecklistItemRequest) (*ChecklistItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateChecklistItem not implemented")
+}
+func (UnimplementedTaskServiceServer) DeleteChecklistItem(context.Context, *DeleteChecklistItemRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteChecklistItem not implemented")
+}
+func (UnimplementedTaskServiceServer) ToggleChecklistItem(context.Context, *ToggleChecklistItemRequest) (*TaskWithStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ToggleChecklistItem not implemented")
+}
 func (UnimplementedTaskServiceServer) mustEmbedUnimplementedTaskServiceServer() {}
 func (UnimplementedTaskServiceServer) testEmbeddedByValue()                     {}
 
@@ -769,6 +1459,42 @@ func _TaskService_UpdateTaskStatus_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaskService_UpdateTaskStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTaskStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).UpdateTaskStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_UpdateTaskStatuses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).UpdateTaskStatuses(ctx, req.(*UpdateTaskStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_UpdateTaskProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTaskProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).UpdateTaskProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_UpdateTaskProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).UpdateTaskProgress(ctx, req.(*UpdateTaskProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TaskService_DeleteTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteTaskRequest)
 	if err := dec(in); err != nil {
@@ -787,6 +1513,276 @@ func _TaskService_DeleteTask_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaskService_RestoreTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).RestoreTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_RestoreTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).RestoreTask(ctx, req.(*RestoreTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_ReorderTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).ReorderTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_ReorderTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).ReorderTasks(ctx, req.(*ReorderTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_GetTagCompletion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagCompletionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).GetTagCompletion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_GetTagCompletion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).GetTagCompletion(ctx, req.(*GetTagCompletionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_GetChallengeProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChallengeProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).GetChallengeProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_GetChallengeProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).GetChallengeProgress(ctx, req.(*GetChallengeProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_GetStreaks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStreaksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).GetStreaks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_GetStreaks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).GetStreaks(ctx, req.(*GetStreaksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_GetTaskStatusHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskStatusHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).GetTaskStatusHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_GetTaskStatusHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).GetTaskStatusHistory(ctx, req.(*GetTaskStatusHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_RequestStatusCorrection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestStatusCorrectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).RequestStatusCorrection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_RequestStatusCorrection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).RequestStatusCorrection(ctx, req.(*RequestStatusCorrectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_ApproveStatusCorrection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewStatusCorrectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).ApproveStatusCorrection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_ApproveStatusCorrection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).ApproveStatusCorrection(ctx, req.(*ReviewStatusCorrectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_RejectStatusCorrection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewStatusCorrectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).RejectStatusCorrection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_RejectStatusCorrection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).RejectStatusCorrection(ctx, req.(*ReviewStatusCorrectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_GetStatusCorrections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusCorrectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).GetStatusCorrections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_GetStatusCorrections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).GetStatusCorrections(ctx, req.(*GetStatusCorrectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_GetChecklistItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChecklistItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).GetChecklistItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_GetChecklistItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).GetChecklistItems(ctx, req.(*GetChecklistItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_AddChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddChecklistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).AddChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_AddChecklistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).AddChecklistItem(ctx, req.(*AddChecklistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_UpdateChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateChecklistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).UpdateChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_UpdateChecklistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).UpdateChecklistItem(ctx, req.(*UpdateChecklistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_DeleteChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteChecklistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).DeleteChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_DeleteChecklistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).DeleteChecklistItem(ctx, req.(*DeleteChecklistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_ToggleChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ToggleChecklistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).ToggleChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_ToggleChecklistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).ToggleChecklistItem(ctx, req.(*ToggleChecklistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TaskService_ServiceDesc is the grpc.ServiceDesc for TaskService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -822,10 +1818,78 @@ var TaskService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateTaskStatus",
 			Handler:    _TaskService_UpdateTaskStatus_Handler,
 		},
+		{
+			MethodName: "UpdateTaskStatuses",
+			Handler:    _TaskService_UpdateTaskStatuses_Handler,
+		},
+		{
+			MethodName: "UpdateTaskProgress",
+			Handler:    _TaskService_UpdateTaskProgress_Handler,
+		},
 		{
 			MethodName: "DeleteTask",
 			Handler:    _TaskService_DeleteTask_Handler,
 		},
+		{
+			MethodName: "RestoreTask",
+			Handler:    _TaskService_RestoreTask_Handler,
+		},
+		{
+			MethodName: "ReorderTasks",
+			Handler:    _TaskService_ReorderTasks_Handler,
+		},
+		{
+			MethodName: "GetTagCompletion",
+			Handler:    _TaskService_GetTagCompletion_Handler,
+		},
+		{
+			MethodName: "GetChallengeProgress",
+			Handler:    _TaskService_GetChallengeProgress_Handler,
+		},
+		{
+			MethodName: "GetStreaks",
+			Handler:    _TaskService_GetStreaks_Handler,
+		},
+		{
+			MethodName: "GetTaskStatusHistory",
+			Handler:    _TaskService_GetTaskStatusHistory_Handler,
+		},
+		{
+			MethodName: "RequestStatusCorrection",
+			Handler:    _TaskService_RequestStatusCorrection_Handler,
+		},
+		{
+			MethodName: "ApproveStatusCorrection",
+			Handler:    _TaskService_ApproveStatusCorrection_Handler,
+		},
+		{
+			MethodName: "RejectStatusCorrection",
+			Handler:    _TaskService_RejectStatusCorrection_Handler,
+		},
+		{
+			MethodName: "GetStatusCorrections",
+			Handler:    _TaskService_GetStatusCorrections_Handler,
+		},
+		{
+			MethodName: "GetChecklistItems",
+			Handler:    _TaskService_GetChecklistItems_Handler,
+		},
+		{
+			MethodName: "AddChecklistItem",
+			Handler:    _TaskService_AddChecklistItem_Handler,
+		},
+		{
+			MethodName: "UpdateChecklistItem",
+			Handler:    _TaskService_UpdateChecklistItem_Handler,
+		},
+		{
+			MethodName: "DeleteChecklistItem",
+			Handler:    _TaskService_DeleteChecklistItem_Handler,
+		},
+		{
+			MethodName: "ToggleChecklistItem",
+			Handler:    _TaskService_ToggleChecklistItem_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "task.proto",
@@ -3,27 +3,38 @@ package model
 import "time"
 
 const (
-	ChallengeStatusDraft    = "DRAFT"
-	ChallengeStatusStarted  = "STARTED"
-	ChallengeStatusFinished = "FINISHED"
+	ChallengeStatusDraft     = "DRAFT"
+	ChallengeStatusScheduled = "SCHEDULED"
+	ChallengeStatusStarted   = "STARTED"
+	ChallengeStatusPaused    = "PAUSED"
+	ChallengeStatusFinished  = "FINISHED"
 
 	ChallengeInvitationStatusPending  = "PENDING"
 	ChallengeInvitationStatusAccepted = "ACCEPTED"
 
 	ChallengeAndUserOwnerRole       = "OWNER"
 	ChallengeAndUserParticipantRole = "PARTICIPANT"
-
-	MaxChallengeDays = 7
 )
 
 type Challenge struct {
-	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
-	Title       string    `gorm:"type:varchar(255);not null" json:"title"`
-	Description string    `gorm:"type:text" json:"description"`
-	StartDate   time.Time `gorm:"type:timestamp;" json:"start_date"`
-	EndDate     time.Time `gorm:"type:timestamp;" json:"end_date"`
-	Status      string    `gorm:"type:varchar(20);not null;check:status IN ('DRAFT', 'STARTED', 'FINISHED')" json:"status"`
-	Days        int32     `gorm:"type:int" json:"days"`
+	ID          int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Title       string     `gorm:"type:varchar(255);not null" json:"title"`
+	Description string     `gorm:"type:text" json:"description"`
+	StartDate   time.Time  `gorm:"type:timestamp;" json:"start_date"`
+	EndDate     time.Time  `gorm:"type:timestamp;" json:"end_date"`
+	Status      string     `gorm:"type:varchar(20);not null;check:status IN ('DRAFT', 'SCHEDULED', 'STARTED', 'PAUSED', 'FINISHED')" json:"status"`
+	Days        int32      `gorm:"type:int" json:"days"`
+	PausedAt    *time.Time `gorm:"type:timestamp" json:"paused_at"`
+
+	// RecurrenceIntervalDays is the number of days between the start dates of two consecutive
+	// iterations of a recurring challenge. Zero means the challenge does not recur.
+	RecurrenceIntervalDays int32  `gorm:"type:int;not null;default:0" json:"recurrence_interval_days"`
+	SeriesID               *int64 `gorm:"uniqueIndex:idx_challenges_series_iteration" json:"series_id"`
+	Iteration              int32  `gorm:"type:int;not null;default:1;uniqueIndex:idx_challenges_series_iteration" json:"iteration"`
+
+	// GraceDays is the number of past days participants may still update their statuses for.
+	// Nil uses the default of the service.
+	GraceDays *int32 `gorm:"type:int" json:"grace_days"`
 }
 
 func (Challenge) TableName() string {
@@ -34,6 +45,7 @@ type ChallengeAndUser struct {
 	ChallengeID int64  `gorm:"primaryKey" json:"challenge_id"`
 	UserID      int64  `gorm:"primaryKey" json:"user_id"`
 	UserRole    string `gorm:"type:varchar(20);not null;check:user_role IN ('OWNER', 'PARTICIPANT')" json:"user_role"`
+	TimeZone    string `gorm:"type:varchar(64);not null;default:'UTC'" json:"time_zone"`
 
 	Challenge Challenge `gorm:"foreignKey:ChallengeID;references:ID;constraint:OnDelete:CASCADE" json:"challenge"`
 }
@@ -42,6 +54,7 @@ type ChallengeInvitation struct {
 	ChallengeID int64  `gorm:"primaryKey" json:"challenge_id"`
 	UserID      int64  `gorm:"primaryKey" json:"user_id"`
 	Status      string `gorm:"type:varchar(20);not null;check:status IN ('PENDING', 'ACCEPTED')" json:"status"`
+	Email       string `gorm:"type:varchar(255)" json:"email"`
 
 	Challenge Challenge `gorm:"foreignKey:ChallengeID;references:ID;constraint:OnDelete:CASCADE" json:"challenge"`
 }
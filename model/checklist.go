@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+type ChecklistItem struct {
+	ID       int64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	TaskID   int64  `gorm:"not null;index" json:"task_id"`
+	Title    string `gorm:"type:varchar(255);not null" json:"title"`
+	Position int32  `gorm:"not null;default:0" json:"position"`
+
+	Task Task `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE;" json:"task"`
+}
+
+func (ChecklistItem) TableName() string {
+	return "checklist_items"
+}
+
+// ChecklistItemState tells whether a participant checked a checklist item on a day.
+// Items without a state for the day are not checked.
+type ChecklistItemState struct {
+	UserID          int64     `gorm:"primaryKey" json:"user_id"`
+	ChecklistItemID int64     `gorm:"primaryKey;not null" json:"checklist_item_id"`
+	Date            time.Time `gorm:"primaryKey;type:date;not null" json:"date"`
+	Checked         bool      `gorm:"not null;default:false" json:"checked"`
+
+	ChecklistItem ChecklistItem `gorm:"foreignKey:ChecklistItemID;constraint:OnDelete:CASCADE;" json:"checklist_item"`
+}
+
+func (ChecklistItemState) TableName() string {
+	return "checklist_item_states"
+}
+
+// StatusForChecklist derives the status of a task from how many of its checklist items are checked for a day.
+func StatusForChecklist(checked, total int64) TaskStatus {
+	switch {
+	case total > 0 && checked == total:
+		return TaskStatusCompleted
+	case checked > 0:
+		return TaskStatusNotCompleted
+	default:
+		return TaskStatusNotStarted
+	}
+}
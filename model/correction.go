@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+const (
+	StatusCorrectionPending  = "PENDING"
+	StatusCorrectionApproved = "APPROVED"
+	StatusCorrectionRejected = "REJECTED"
+)
+
+// StatusCorrection is a request of a participant to change a status outside of the grace period of the challenge.
+// The owner of the challenge approves or rejects it.
+type StatusCorrection struct {
+	ID                int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	TaskID            int64      `gorm:"not null;index" json:"task_id"`
+	UserID            int64      `gorm:"not null;index" json:"user_id"`
+	Date              time.Time  `gorm:"type:date;not null" json:"date"`
+	RequestedStatus   TaskStatus `gorm:"type:varchar(20);not null" json:"requested_status"`
+	RequestedProgress *float64   `gorm:"type:double precision" json:"requested_progress"`
+	Reason            string     `gorm:"type:text" json:"reason"`
+	Status            string     `gorm:"type:varchar(20);not null;default:'PENDING';check:status IN ('PENDING', 'APPROVED', 'REJECTED')" json:"status"`
+	ReviewerID        *int64     `json:"reviewer_id"`
+	RequestedAt       time.Time  `gorm:"type:timestamp;not null" json:"requested_at"`
+	ReviewedAt        *time.Time `gorm:"type:timestamp" json:"reviewed_at"`
+
+	Task Task `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE;" json:"task"`
+}
+
+func (StatusCorrection) TableName() string {
+	return "status_corrections"
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// TaskStatusChange is an entry of the append-only history of the status of a task for a participant and a day.
+type TaskStatusChange struct {
+	ID        int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	TaskID    int64      `gorm:"not null;index:idx_task_status_changes_status" json:"task_id"`
+	UserID    int64      `gorm:"not null;index:idx_task_status_changes_status" json:"user_id"`
+	Date      time.Time  `gorm:"type:date;not null;index:idx_task_status_changes_status" json:"date"`
+	OldStatus TaskStatus `gorm:"type:varchar(20);not null" json:"old_status"`
+	NewStatus TaskStatus `gorm:"type:varchar(20);not null" json:"new_status"`
+	// ActorID is nil for changes made by the service itself, like marking missed days.
+	ActorID   *int64    `json:"actor_id"`
+	ChangedAt time.Time `gorm:"type:timestamp;not null" json:"changed_at"`
+
+	Task Task `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE;" json:"task"`
+}
+
+func (TaskStatusChange) TableName() string {
+	return "task_status_changes"
+}
@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// Streak is the number of consecutive successful days of a participant. It is kept up to date as the statuses
+// of the participant change, so that reading it is cheap. TaskID is 0 for the challenge streak, where a day is
+// successful when every required task is completed.
+type Streak struct {
+	ChallengeID int64 `gorm:"primaryKey" json:"challenge_id"`
+	UserID      int64 `gorm:"primaryKey" json:"user_id"`
+	TaskID      int64 `gorm:"primaryKey" json:"task_id"`
+	Current     int32 `gorm:"not null;default:0" json:"current"`
+	Longest     int32 `gorm:"not null;default:0" json:"longest"`
+	// ExpiresAfter is the day the current streak has to be continued on. The streak is broken once that day is over.
+	// It is nil when no day is left to continue it on.
+	ExpiresAfter *time.Time `gorm:"type:date" json:"expires_after"`
+
+	Challenge Challenge `gorm:"foreignKey:ChallengeID;constraint:OnDelete:CASCADE" json:"challenge"`
+}
+
+func (Streak) TableName() string {
+	return "streaks"
+}
+
+// CurrentOn returns the current streak as of the given day.
+func (s *Streak) CurrentOn(today time.Time) int32 {
+	if s.ExpiresAfter != nil && today.After(*s.ExpiresAfter) {
+		return 0
+	}
+
+	return s.Current
+}
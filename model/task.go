@@ -5,33 +5,194 @@ import (
 )
 
 const (
-	TaskStatusNotStarted TaskStatus = "NOT_STARTED"
+	TaskStatusNotStarted   TaskStatus = "NOT_STARTED"
+	TaskStatusCompleted    TaskStatus = "COMPLETED"
+	TaskStatusNotCompleted TaskStatus = "NOT_COMPLETED"
+	TaskStatusSkipped      TaskStatus = "SKIPPED"
+	TaskStatusExcused      TaskStatus = "EXCUSED"
+
+	RecurrenceWeekDays      = "WEEK_DAYS"
+	RecurrenceEveryDay      = "EVERY_DAY"
+	RecurrenceEveryNDays    = "EVERY_N_DAYS"
+	RecurrenceTimesPerWeek  = "TIMES_PER_WEEK"
+	RecurrenceSpecificDates = "SPECIFIC_DATES"
+
+	AllWeekDays WeekDays = 1<<7 - 1
 )
 
 type TaskStatus string
 type WeekDays int32
 
+// taskStatusTransitions lists the statuses each status may be changed to by a participant. Completed tasks
+// cannot be turned into neutral ones directly, and excused tasks can only be reset.
+var taskStatusTransitions = map[TaskStatus][]TaskStatus{
+	TaskStatusNotStarted:   {TaskStatusCompleted, TaskStatusNotCompleted, TaskStatusSkipped, TaskStatusExcused},
+	TaskStatusCompleted:    {TaskStatusNotStarted, TaskStatusNotCompleted},
+	TaskStatusNotCompleted: {TaskStatusNotStarted, TaskStatusCompleted, TaskStatusSkipped, TaskStatusExcused},
+	TaskStatusSkipped:      {TaskStatusNotStarted, TaskStatusCompleted, TaskStatusNotCompleted},
+	TaskStatusExcused:      {TaskStatusNotStarted},
+}
+
+// NeutralTaskStatuses count neither as completed nor as missed in completion metrics.
+var NeutralTaskStatuses = []TaskStatus{TaskStatusSkipped, TaskStatusExcused}
+
+func (s TaskStatus) IsValid() bool {
+	_, ok := taskStatusTransitions[s]
+	return ok
+}
+
+func (s TaskStatus) IsNeutral() bool {
+	return s == TaskStatusSkipped || s == TaskStatusExcused
+}
+
+// CanTransitionTo reports whether the status may be changed to next. Keeping the same status is always allowed.
+func (s TaskStatus) CanTransitionTo(next TaskStatus) bool {
+	if s == next {
+		return true
+	}
+
+	for _, status := range taskStatusTransitions[s] {
+		if status == next {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (w *WeekDays) Includes(weekDay time.Weekday) bool {
 	return (*w & (1 << int32(weekDay))) != 0
 }
 
+// Recurrence tells on which days of a challenge a task is due. Tasks created before recurrences
+// existed have the WEEK_DAYS type and keep using their WeekDays.
+type Recurrence struct {
+	Type string `gorm:"type:varchar(20);not null;default:'WEEK_DAYS';check:recurrence_type IN ('WEEK_DAYS', 'EVERY_DAY', 'EVERY_N_DAYS', 'TIMES_PER_WEEK', 'SPECIFIC_DATES')" json:"type"`
+	// Interval is the N of EVERY_N_DAYS and the number of times a week of TIMES_PER_WEEK.
+	Interval int32       `gorm:"not null;default:0" json:"interval"`
+	Dates    []time.Time `gorm:"type:jsonb;serializer:json" json:"dates"`
+}
+
+// TimeWindow is the time of day a task is meant to be done in, as minutes since midnight in the
+// participant's time zone. Either both ends are set or none is.
+type TimeWindow struct {
+	Start *int32 `gorm:"type:smallint" json:"start"`
+	End   *int32 `gorm:"type:smallint" json:"end"`
+}
+
+func (w TimeWindow) IsSet() bool {
+	return w.Start != nil && w.End != nil
+}
+
+// Equal reports whether both recurrences schedule a task on the same days.
+func (r Recurrence) Equal(other Recurrence) bool {
+	if r.Type != other.Type || r.Interval != other.Interval || len(r.Dates) != len(other.Dates) {
+		return false
+	}
+
+	for i := range r.Dates {
+		if !r.Dates[i].Equal(other.Dates[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
 type Task struct {
-	ID          int64    `gorm:"primaryKey;autoIncrement" json:"id"`
-	Title       string   `gorm:"type:varchar(255);not null" json:"title"`
-	WeekDays    WeekDays `gorm:"not null" json:"week_days"`
-	Description string   `gorm:"type:text" json:"description"`
-	ChallengeID int64    `gorm:"not null;index;constraint:OnDelete:CASCADE;" json:"challenge_id"`
+	ID          int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Title       string     `gorm:"type:varchar(255);not null" json:"title"`
+	WeekDays    WeekDays   `gorm:"not null" json:"week_days"`
+	Description string     `gorm:"type:text" json:"description"`
+	ChallengeID int64      `gorm:"not null;index;constraint:OnDelete:CASCADE;" json:"challenge_id"`
+	ArchivedAt  *time.Time `gorm:"type:timestamp" json:"archived_at"`
+	TargetValue *float64   `gorm:"type:double precision" json:"target_value"`
+	Unit        string     `gorm:"type:varchar(50)" json:"unit"`
+	Position    int32      `gorm:"not null;default:0" json:"position"`
+	Tags        []string   `gorm:"type:jsonb;serializer:json" json:"tags"`
+	Recurrence  Recurrence `gorm:"embedded;embeddedPrefix:recurrence_" json:"recurrence"`
+	Window      TimeWindow `gorm:"embedded;embeddedPrefix:window_" json:"window"`
+	Assignees   []int64    `gorm:"type:jsonb;serializer:json" json:"assignees"`
+	Required    *bool      `gorm:"not null;default:true" json:"required"`
+	Weight      int32      `gorm:"not null;default:1" json:"weight"`
 }
 
 func (Task) TableName() string {
 	return "tasks"
 }
 
+// IsDueOn reports whether the task is due on the given day of a challenge that started on challengeStart.
+// TIMES_PER_WEEK tasks are due every day, and participants pick the days they complete them on. Their weekly
+// quota is checked once the week is over.
+func (t *Task) IsDueOn(date, challengeStart time.Time) bool {
+	switch t.Recurrence.Type {
+	case RecurrenceEveryDay, RecurrenceTimesPerWeek:
+		return true
+	case RecurrenceEveryNDays:
+		days := int32(date.Sub(challengeStart).Hours() / 24)
+		return days >= 0 && days%t.Recurrence.Interval == 0
+	case RecurrenceSpecificDates:
+		for _, d := range t.Recurrence.Dates {
+			if d.Equal(date) {
+				return true
+			}
+		}
+		return false
+	default:
+		return t.WeekDays.Includes(date.Weekday())
+	}
+}
+
+// WeeklyQuota is the number of times a TIMES_PER_WEEK task has to be completed in a week the challenge has the
+// given number of days in. Weeks the challenge only partly covers ask for a proportional share, rounded up.
+func (t *Task) WeeklyQuota(days int) int {
+	return (int(t.Recurrence.Interval)*days + 6) / 7
+}
+
+// IsAssignedTo reports whether the task applies to the user. Tasks without assignees apply to every participant.
+func (t *Task) IsAssignedTo(userId int64) bool {
+	if len(t.Assignees) == 0 {
+		return true
+	}
+
+	for _, assignee := range t.Assignees {
+		if assignee == userId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsRequired reports whether the task has to be completed for a day to be successful.
+func (t *Task) IsRequired() bool {
+	return t.Required == nil || *t.Required
+}
+
+// IsQuantitative reports whether the task is measured against a target value instead of being simply done or not.
+func (t *Task) IsQuantitative() bool {
+	return t.TargetValue != nil
+}
+
+// StatusForProgress derives the status of a quantitative task from the progress reported for a day.
+func (t *Task) StatusForProgress(progress float64) TaskStatus {
+	switch {
+	case progress >= *t.TargetValue:
+		return TaskStatusCompleted
+	case progress > 0:
+		return TaskStatusNotCompleted
+	default:
+		return TaskStatusNotStarted
+	}
+}
+
 type TaskAndStatus struct {
-	UserID int64      `gorm:"primaryKey" json:"user_id"`
-	TaskID int64      `gorm:"primaryKey;not null" json:"task_id"`
-	Date   time.Time  `gorm:"primaryKey;type:date;not null" json:"date"`
-	Status TaskStatus `gorm:"type:varchar(20);not null;check:status IN ('NOT_STARTED', 'COMPLETED', 'NOT_COMPLETED')" json:"status"`
+	UserID   int64      `gorm:"primaryKey" json:"user_id"`
+	TaskID   int64      `gorm:"primaryKey;not null" json:"task_id"`
+	Date     time.Time  `gorm:"primaryKey;type:date;not null" json:"date"`
+	Status   TaskStatus `gorm:"type:varchar(20);not null;check:status IN ('NOT_STARTED', 'COMPLETED', 'NOT_COMPLETED', 'SKIPPED', 'EXCUSED')" json:"status"`
+	Progress float64    `gorm:"type:double precision;not null;default:0" json:"progress"`
+	Late     bool       `gorm:"not null;default:false" json:"late"`
 
 	Task Task `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE;" json:"task"`
 }
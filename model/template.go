@@ -0,0 +1,35 @@
+package model
+
+type ChallengeTemplate struct {
+	ID          int64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      int64  `gorm:"not null;index" json:"user_id"`
+	Title       string `gorm:"type:varchar(255);not null" json:"title"`
+	Description string `gorm:"type:text" json:"description"`
+	Days        int32  `gorm:"type:int" json:"days"`
+
+	Tasks []ChallengeTemplateTask `gorm:"foreignKey:TemplateID;constraint:OnDelete:CASCADE" json:"tasks"`
+}
+
+func (ChallengeTemplate) TableName() string {
+	return "challenge_templates"
+}
+
+type ChallengeTemplateTask struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	TemplateID  int64      `gorm:"not null;index" json:"template_id"`
+	Title       string     `gorm:"type:varchar(255);not null" json:"title"`
+	WeekDays    WeekDays   `gorm:"not null" json:"week_days"`
+	Description string     `gorm:"type:text" json:"description"`
+	TargetValue *float64   `gorm:"type:double precision" json:"target_value"`
+	Unit        string     `gorm:"type:varchar(50)" json:"unit"`
+	Tags        []string   `gorm:"type:jsonb;serializer:json" json:"tags"`
+	Recurrence  Recurrence `gorm:"embedded;embeddedPrefix:recurrence_" json:"recurrence"`
+	Window      TimeWindow `gorm:"embedded;embeddedPrefix:window_" json:"window"`
+	Checklist   []string   `gorm:"type:jsonb;serializer:json" json:"checklist"`
+	Required    *bool      `gorm:"not null;default:true" json:"required"`
+	Weight      int32      `gorm:"not null;default:1" json:"weight"`
+}
+
+func (ChallengeTemplateTask) TableName() string {
+	return "challenge_template_tasks"
+}
@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"log"
+	"ryg-task-service/conf"
+	"time"
+)
+
+type Job struct {
+	Name string
+	Run  func() error
+}
+
+type Scheduler struct {
+	interval time.Duration
+	jobs     []Job
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func NewScheduler(cnf conf.SchedulerConfig, jobs ...Job) *Scheduler {
+	return &Scheduler{
+		interval: cnf.Interval,
+		jobs:     jobs,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start runs every job once right away and then again on each tick until Stop is called.
+func (s *Scheduler) Start() {
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.runJobs()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.runJobs()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+
+	log.Printf("Scheduler started with interval %v", s.interval)
+}
+
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	<-s.done
+
+	log.Printf("Scheduler stopped")
+}
+
+func (s *Scheduler) runJobs() {
+	for _, job := range s.jobs {
+		if err := job.Run(); err != nil {
+			log.Printf("Scheduler job %s failed: %v", job.Name, err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"ryg-task-service/model"
+	"slices"
+	"sort"
+)
+
+// normalizeAssignees drops repeated assignees and sorts them, so that assignments can be compared.
+func normalizeAssignees(userIds []int64) []int64 {
+	assignees := make([]int64, 0, len(userIds))
+	seen := make(map[int64]bool, len(userIds))
+
+	for _, userId := range userIds {
+		if seen[userId] {
+			continue
+		}
+
+		seen[userId] = true
+		assignees = append(assignees, userId)
+	}
+
+	sort.Slice(assignees, func(i, j int) bool {
+		return assignees[i] < assignees[j]
+	})
+
+	return assignees
+}
+
+func sameAssignees(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// participatingAssignees returns the assignees who are among the participants.
+func participatingAssignees(assignees []int64, participantIds []int64) []int64 {
+	if len(assignees) == 0 {
+		return assignees
+	}
+
+	participating := make([]int64, 0, len(assignees))
+
+	for _, assignee := range assignees {
+		if slices.Contains(participantIds, assignee) {
+			participating = append(participating, assignee)
+		}
+	}
+
+	return participating
+}
+
+// validateTaskAssignees checks that every assignee takes part in the challenge.
+func validateTaskAssignees(tx *gorm.DB, challengeId int64, assignees []int64) error {
+	if len(assignees) == 0 {
+		return nil
+	}
+
+	var participants int64
+
+	if err := tx.Model(&model.ChallengeAndUser{}).Where("challenge_id = ? AND user_id IN ?", challengeId, assignees).Count(&participants).Error; err != nil {
+		return err
+	}
+
+	if participants != int64(len(assignees)) {
+		return status.Error(400, "Task can only be assigned to participants of the challenge")
+	}
+
+	return nil
+}
+
+func validateTaskAssignedToUser(task *model.Task, userId int64) error {
+	if !task.IsAssignedTo(userId) {
+		return status.Error(403, "Task is not assigned to the user")
+	}
+
+	return nil
+}
+
+// syncAssignedTaskStatuses brings the statuses of the remaining days in line with the assignees of the task.
+// Users no longer assigned lose their not started statuses, and newly assigned users get theirs generated.
+func (s *TaskService) syncAssignedTaskStatuses(tx *gorm.DB, challenge *model.Challenge, task *model.Task, userId int64) error {
+	if challenge.Status != model.ChallengeStatusStarted && challenge.Status != model.ChallengeStatusPaused {
+		return nil
+	}
+
+	today, err := s.ChallengeSvs.userToday(challenge.ID, userId)
+	if err != nil {
+		return err
+	}
+
+	if len(task.Assignees) > 0 {
+		err := tx.WithContext(context.Background()).
+			Where("task_id = ? AND status = ? AND date >= ? AND user_id NOT IN ?", task.ID, model.TaskStatusNotStarted, today, task.Assignees).
+			Delete(&model.TaskAndStatus{}).Error
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if challenge.Status == model.ChallengeStatusStarted {
+		taskAndStatuses, err := s.buildTaskAndStatusesForRemainingDays(tx, challenge, task)
+		if err != nil {
+			return err
+		}
+
+		if err := createTaskAndStatuses(tx, taskAndStatuses); err != nil {
+			return err
+		}
+	}
+
+	return s.refreshStreaksOfParticipants(tx, challenge.ID, []int64{task.ID})
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	pb "ryg-task-service/gen_proto/task_service"
+	"ryg-task-service/model"
+	"time"
+)
+
+type taskStatusUpdate struct {
+	taskAndStatus *model.TaskAndStatus
+	newStatus     model.TaskStatus
+	result        *pb.TaskStatusResult
+}
+
+// UpdateTaskStatuses sets the statuses of many tasks of the user for one day. Every update goes through the checks
+// of UpdateTaskStatus, and the updates are applied all together or not at all.
+func (s *TaskService) UpdateTaskStatuses(ctx context.Context, req *pb.UpdateTaskStatusesRequest) (*pb.UpdateTaskStatusesResponse, error) {
+	if _, err := s.ChallengeSvs.ValidateUserCanReadChallenge(req.ChallengeId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateTaskStatusDate(req.ChallengeId, req.UserId, req.Date); err != nil {
+		return nil, err
+	}
+
+	var taskAndStatuses []model.TaskAndStatus
+
+	err := s.db.WithContext(ctx).
+		Preload("Task").
+		Joins("JOIN tasks ON tasks.id = task_and_status.task_id").
+		Where("tasks.challenge_id = ? AND task_and_status.date = ? AND task_and_status.user_id = ?", req.ChallengeId, req.Date.AsTime(), req.UserId).
+		Order("tasks.position, tasks.id").
+		Find(&taskAndStatuses).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	requests := req.Updates
+	if req.MarkAllCompleted {
+		now, err := s.ChallengeSvs.userNow(req.ChallengeId, req.UserId)
+		if err != nil {
+			return nil, err
+		}
+
+		requests = completableTaskStatusUpdates(taskAndStatuses, now)
+	}
+
+	if len(requests) == 0 && !req.MarkAllCompleted {
+		return nil, status.Error(400, "No task statuses to update")
+	}
+
+	taskAndStatusesByTaskId := make(map[int64]*model.TaskAndStatus, len(taskAndStatuses))
+	for i := range taskAndStatuses {
+		taskAndStatusesByTaskId[taskAndStatuses[i].TaskID] = &taskAndStatuses[i]
+	}
+
+	resp := &pb.UpdateTaskStatusesResponse{
+		Applied: true,
+		Results: make([]*pb.TaskStatusResult, 0, len(requests)),
+	}
+
+	updates := make([]taskStatusUpdate, 0, len(requests))
+
+	for _, request := range requests {
+		result := &pb.TaskStatusResult{TaskId: request.TaskId}
+		resp.Results = append(resp.Results, result)
+
+		taskAndStatus, newStatus, err := s.validateTaskStatusUpdate(request, taskAndStatusesByTaskId)
+		if err != nil {
+			result.Error = status.Convert(err).Message()
+			resp.Applied = false
+			continue
+		}
+
+		// A task only gets updated once, so a repeated update finds it gone.
+		delete(taskAndStatusesByTaskId, request.TaskId)
+
+		updates = append(updates, taskStatusUpdate{taskAndStatus: taskAndStatus, newStatus: newStatus, result: result})
+	}
+
+	if !resp.Applied {
+		return resp, nil
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		changedTaskIds := make([]int64, 0, len(updates))
+
+		for _, update := range updates {
+			changed, err := s.writeTaskStatus(tx, update.taskAndStatus, update.newStatus, req.UserId)
+			if err != nil {
+				return err
+			}
+
+			if changed {
+				changedTaskIds = append(changedTaskIds, update.taskAndStatus.TaskID)
+			}
+		}
+
+		if len(changedTaskIds) == 0 {
+			return nil
+		}
+
+		return s.refreshStreaks(tx, req.ChallengeId, req.UserId, changedTaskIds, req.Date.AsTime())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, update := range updates {
+		update.result.TaskWithStatus = toPbTaskWithStatus(update.taskAndStatus)
+	}
+
+	return resp, nil
+}
+
+func (s *TaskService) validateTaskStatusUpdate(update *pb.TaskStatusUpdate, taskAndStatuses map[int64]*model.TaskAndStatus) (*model.TaskAndStatus, model.TaskStatus, error) {
+	newStatus, err := taskStatusFromPb(update.Status)
+	if err != nil {
+		return nil, "", err
+	}
+
+	taskAndStatus, ok := taskAndStatuses[update.TaskId]
+	if !ok {
+		return nil, "", status.Error(404, "Task is not scheduled for the date or is updated more than once")
+	}
+
+	if err := validateTaskAssignedToUser(&taskAndStatus.Task, taskAndStatus.UserID); err != nil {
+		return nil, "", err
+	}
+
+	if err := s.validateTaskStatusChange(taskAndStatus, &taskAndStatus.Task, newStatus); err != nil {
+		return nil, "", err
+	}
+
+	return taskAndStatus, newStatus, nil
+}
+
+// completableTaskStatusUpdates completes every task of the day that can still be completed by hand at the user's
+// local time now. Tasks that are already completed, quantitative, archived, excused, no longer assigned to the user
+// or whose window has not opened yet are left as they are.
+func completableTaskStatusUpdates(taskAndStatuses []model.TaskAndStatus, now time.Time) []*pb.TaskStatusUpdate {
+	today := calendarDay(now, now.Location())
+	updates := make([]*pb.TaskStatusUpdate, 0, len(taskAndStatuses))
+
+	for _, taskAndStatus := range taskAndStatuses {
+		task := taskAndStatus.Task
+
+		if taskAndStatus.Status == model.TaskStatusCompleted || task.IsQuantitative() || task.ArchivedAt != nil {
+			continue
+		}
+
+		if !task.IsAssignedTo(taskAndStatus.UserID) {
+			continue
+		}
+
+		// Past days of the grace period can be completed whatever the window.
+		if !taskAndStatus.Date.Before(today) && isBeforeWindow(task.Window, now) {
+			continue
+		}
+
+		if !taskAndStatus.Status.CanTransitionTo(model.TaskStatusCompleted) {
+			continue
+		}
+
+		updates = append(updates, &pb.TaskStatusUpdate{
+			TaskId: taskAndStatus.TaskID,
+			Status: pb.TaskStatus_COMPLETED,
+		})
+	}
+
+	return updates
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"ryg-task-service/model"
+	"testing"
+	"time"
+)
+
+func TestCompletableTaskStatusUpdates(t *testing.T) {
+	// The participant marks everything done at 08:00 on the 12th of March in their time zone.
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatalf("load time zone: %v", err)
+	}
+
+	now := time.Date(2026, time.March, 12, 8, 0, 0, 0, loc)
+	start, end := int32(9*60), int32(12*60)
+
+	tests := []struct {
+		name string
+		task model.Task
+		date time.Time
+		// status defaults to not started.
+		status model.TaskStatus
+		want   bool
+	}{
+		{name: "task without window", date: date(2026, time.March, 12), want: true},
+		{
+			name: "window not opened yet",
+			task: model.Task{Window: model.TimeWindow{Start: &start, End: &end}},
+			date: date(2026, time.March, 12),
+		},
+		{
+			name: "past day of a window not opened yet today",
+			task: model.Task{Window: model.TimeWindow{Start: &start, End: &end}},
+			date: date(2026, time.March, 11),
+			want: true,
+		},
+		{name: "already completed", date: date(2026, time.March, 12), status: model.TaskStatusCompleted},
+		{name: "assigned to someone else", task: model.Task{Assignees: []int64{testOwnerId}}, date: date(2026, time.March, 12)},
+		{name: "assigned to the participant", task: model.Task{Assignees: []int64{testParticipantId}}, date: date(2026, time.March, 12), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskStatus := tt.status
+			if taskStatus == "" {
+				taskStatus = model.TaskStatusNotStarted
+			}
+
+			tt.task.ID = 1
+
+			updates := completableTaskStatusUpdates([]model.TaskAndStatus{{
+				TaskID: tt.task.ID,
+				Task:   tt.task,
+				UserID: testParticipantId,
+				Date:   tt.date,
+				Status: taskStatus,
+			}}, now)
+
+			if got := len(updates) == 1; got != tt.want {
+				t.Errorf("completed = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
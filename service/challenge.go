@@ -2,11 +2,14 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
+	"ryg-task-service/clock"
+	"ryg-task-service/conf"
 	"ryg-task-service/gen_proto/email_service"
 	pb "ryg-task-service/gen_proto/task_service"
 	"ryg-task-service/model"
@@ -16,20 +19,30 @@ import (
 
 type ChallengeService struct {
 	db                    *gorm.DB
+	clock                 clock.Clock
+	maxChallengeDays      int32
+	defaultGraceDays      int32
 	TaskSvs               *TaskService
 	GenericEmailPublisher rabbit_mq.Publisher[*email_service.GenericEmail]
 	pb.UnimplementedChallengeServiceServer
 }
 
-func NewChallengeService[P rabbit_mq.Publisher[*email_service.GenericEmail]](db *gorm.DB, genericEmailPublisher P) *ChallengeService {
+func NewChallengeService[P rabbit_mq.Publisher[*email_service.GenericEmail]](db *gorm.DB, genericEmailPublisher P, clk clock.Clock, cnf conf.ChallengeConfig) *ChallengeService {
 	return &ChallengeService{
 		db:                    db,
+		clock:                 clk,
+		maxChallengeDays:      cnf.MaxDays,
+		defaultGraceDays:      cnf.GraceDays,
 		GenericEmailPublisher: genericEmailPublisher,
 	}
 }
 
 func (s *ChallengeService) CreateChallenge(ctx context.Context, req *pb.CreateChallengeRequest) (*pb.Challenge, error) {
-	if err := validateCreateChallengeRequest(req); err != nil {
+	if err := s.validateCreateChallengeRequest(req); err != nil {
+		return nil, err
+	}
+
+	if _, err := loadTimeZone(req.TimeZone); err != nil {
 		return nil, err
 	}
 
@@ -38,52 +51,50 @@ func (s *ChallengeService) CreateChallenge(ctx context.Context, req *pb.CreateCh
 		Description: req.Description,
 		Status:      model.ChallengeStatusDraft,
 		Days:        req.Days,
+		Iteration:   1,
 	}
 
 	err := s.db.Transaction(func(tx *gorm.DB) error {
-		if err := s.db.WithContext(context.Background()).Create(&challenge).Error; err != nil {
-			return err
-		}
-
-		challengeAndUser := &model.ChallengeAndUser{
-			ChallengeID: challenge.ID,
-			UserID:      req.UserId,
-			UserRole:    model.ChallengeAndUserOwnerRole,
-		}
-
-		if err := s.db.WithContext(context.Background()).Create(&challengeAndUser).Error; err != nil {
-			return err
-		}
-
-		return nil
+		return createChallengeOwnedByUser(tx, challenge, req.UserId, req.TimeZone)
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	resp := &pb.Challenge{
-		Id:          challenge.ID,
-		Title:       challenge.Title,
-		Description: challenge.Description,
-		Status:      challenge.Status,
-		Days:        challenge.Days,
+	return toPbChallenge(challenge), nil
+}
+
+func createChallengeOwnedByUser(tx *gorm.DB, challenge *model.Challenge, userId int64, timeZone string) error {
+	if err := tx.WithContext(context.Background()).Create(&challenge).Error; err != nil {
+		return err
 	}
 
-	return resp, nil
+	challengeAndUser := &model.ChallengeAndUser{
+		ChallengeID: challenge.ID,
+		UserID:      userId,
+		UserRole:    model.ChallengeAndUserOwnerRole,
+		TimeZone:    timeZoneOrUTC(timeZone),
+	}
+
+	return tx.WithContext(context.Background()).Create(&challengeAndUser).Error
 }
 
-func validateCreateChallengeRequest(req *pb.CreateChallengeRequest) error {
+func (s *ChallengeService) validateCreateChallengeRequest(req *pb.CreateChallengeRequest) error {
 	if req.Title == "" {
 		return status.Error(400, "Title is required")
 	}
 
-	if req.Days <= 0 {
+	return s.validateChallengeDays(req.Days)
+}
+
+func (s *ChallengeService) validateChallengeDays(days int32) error {
+	if days <= 0 {
 		return status.Error(400, "Days must be greater than 0")
 	}
 
-	if req.Days > model.MaxChallengeDays {
-		return status.Error(400, fmt.Sprintf("Days cannot be greater than %d", model.MaxChallengeDays))
+	if days > s.maxChallengeDays {
+		return status.Error(400, fmt.Sprintf("Days cannot be greater than %d", s.maxChallengeDays))
 	}
 
 	return nil
@@ -95,16 +106,19 @@ func (s *ChallengeService) GetChallengeById(ctx context.Context, req *pb.GetChal
 		return nil, err
 	}
 
-	resp := &pb.Challenge{
-		Id:          challenge.ID,
-		Title:       challenge.Title,
-		Description: challenge.Description,
-		StartDate:   timestamppb.New(challenge.StartDate),
-		EndDate:     timestamppb.New(challenge.EndDate),
-		Status:      challenge.Status,
-		Days:        challenge.Days,
+	today, err := s.userToday(challenge.ID, req.UserId)
+	if err != nil {
+		return nil, err
 	}
 
+	streak, err := getStreak(s.db, challenge.ID, req.UserId, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toPbChallenge(challenge)
+	resp.Streak = toPbStreak(0, streak, today)
+
 	return resp, nil
 }
 
@@ -121,15 +135,7 @@ func (s *ChallengeService) GetChallengesByUserId(ctx context.Context, req *pb.Ge
 
 	for _, challengeAndUser := range challengeAndUsers {
 		challenge := challengeAndUser.Challenge
-		resp.Challenges = append(resp.Challenges, &pb.Challenge{
-			Id:          challenge.ID,
-			Title:       challenge.Title,
-			Description: challenge.Description,
-			StartDate:   timestamppb.New(challenge.StartDate),
-			EndDate:     timestamppb.New(challenge.EndDate),
-			Status:      challenge.Status,
-			Days:        challenge.Days,
-		})
+		resp.Challenges = append(resp.Challenges, toPbChallenge(&challenge))
 	}
 
 	return resp, nil
@@ -141,11 +147,11 @@ func (s *ChallengeService) UpdateChallenge(ctx context.Context, req *pb.UpdateCh
 		return nil, err
 	}
 
-	if challenge.Status != model.ChallengeStatusDraft {
+	if challenge.Status != model.ChallengeStatusDraft && challenge.Status != model.ChallengeStatusScheduled {
 		return nil, status.Error(400, "Cannot update started or finished challenge")
 	}
 
-	if err := validateUpdateChallengeRequest(req); err != nil {
+	if err := s.validateUpdateChallengeRequest(req); err != nil {
 		return nil, err
 	}
 
@@ -156,32 +162,15 @@ func (s *ChallengeService) UpdateChallenge(ctx context.Context, req *pb.UpdateCh
 		return nil, err
 	}
 
-	resp := &pb.Challenge{
-		Id:          challenge.ID,
-		Title:       challenge.Title,
-		Description: challenge.Description,
-		StartDate:   timestamppb.New(challenge.StartDate),
-		EndDate:     timestamppb.New(challenge.EndDate),
-		Status:      challenge.Status,
-	}
-
-	return resp, nil
+	return toPbChallenge(challenge), nil
 }
 
-func validateUpdateChallengeRequest(req *pb.UpdateChallengeRequest) error {
+func (s *ChallengeService) validateUpdateChallengeRequest(req *pb.UpdateChallengeRequest) error {
 	if req.Title == "" {
 		return status.Error(400, "Title is required")
 	}
 
-	if req.Days <= 0 {
-		return status.Error(400, "Days must be greater than 0")
-	}
-
-	if req.Days > model.MaxChallengeDays {
-		return status.Error(400, fmt.Sprintf("Days cannot be greater than %d", model.MaxChallengeDays))
-	}
-
-	return nil
+	return s.validateChallengeDays(req.Days)
 }
 
 func (s *ChallengeService) DeleteChallenge(ctx context.Context, req *pb.DeleteChallengeRequest) (*emptypb.Empty, error) {
@@ -198,6 +187,111 @@ func (s *ChallengeService) DeleteChallenge(ctx context.Context, req *pb.DeleteCh
 	return &emptypb.Empty{}, nil
 }
 
+func (s *ChallengeService) CloneChallenge(ctx context.Context, req *pb.CloneChallengeRequest) (*pb.Challenge, error) {
+	original, err := s.ValidateUserCanReadChallenge(req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if original.Status != model.ChallengeStatusFinished {
+		return nil, status.Error(400, "Cannot clone draft or started challenge")
+	}
+
+	if err := s.validateChallengeDays(original.Days); err != nil {
+		return nil, err
+	}
+
+	if _, err := loadTimeZone(req.TimeZone); err != nil {
+		return nil, err
+	}
+
+	challenge := &model.Challenge{
+		Title:       original.Title,
+		Description: original.Description,
+		Status:      model.ChallengeStatusDraft,
+		Days:        original.Days,
+		Iteration:   1,
+		GraceDays:   original.GraceDays,
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := createChallengeOwnedByUser(tx, challenge, req.UserId, req.TimeZone); err != nil {
+			return err
+		}
+
+		if err := copyTasks(tx, original.ID, challenge.ID, 0); err != nil {
+			return err
+		}
+
+		if !req.ReinviteParticipants {
+			return nil
+		}
+
+		return s.reinviteParticipants(tx, original.ID, challenge.ID, req.UserId)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toPbChallenge(challenge), nil
+}
+
+// reinviteParticipants invites the participants of the original challenge to the new one. The email of a
+// participant is taken from their original invitation, so participants without one cannot be re-invited.
+func (s *ChallengeService) reinviteParticipants(tx *gorm.DB, originalChallengeID, challengeID, ownerID int64) error {
+	participantIds := make([]int64, 0)
+
+	if err := tx.Model(&model.ChallengeAndUser{}).
+		Where("challenge_id = ? AND user_role = ? AND user_id <> ?", originalChallengeID, model.ChallengeAndUserParticipantRole, ownerID).
+		Order("user_id").
+		Pluck("user_id", &participantIds).Error; err != nil {
+		return err
+	}
+
+	var originalInvitations []model.ChallengeInvitation
+
+	if err := tx.WithContext(context.Background()).Where("challenge_id = ? AND user_id IN ? AND email <> ''", originalChallengeID, participantIds).Find(&originalInvitations).Error; err != nil {
+		return err
+	}
+
+	invitedUserIds := make(map[int64]bool, len(originalInvitations))
+	for _, originalInvitation := range originalInvitations {
+		invitedUserIds[originalInvitation.UserID] = true
+	}
+
+	var missingEmailUserIds []int64
+
+	for _, participantId := range participantIds {
+		if !invitedUserIds[participantId] {
+			missingEmailUserIds = append(missingEmailUserIds, participantId)
+		}
+	}
+
+	if len(missingEmailUserIds) > 0 {
+		return status.Error(400, fmt.Sprintf("Cannot re-invite participants without a stored email: %v", missingEmailUserIds))
+	}
+
+	for _, originalInvitation := range originalInvitations {
+		challengeInvitation := &model.ChallengeInvitation{
+			ChallengeID: challengeID,
+			UserID:      originalInvitation.UserID,
+			Status:      model.ChallengeInvitationStatusPending,
+			Email:       originalInvitation.Email,
+		}
+
+		if err := tx.WithContext(context.Background()).Create(&challengeInvitation).Error; err != nil {
+			return err
+		}
+
+		if err := s.sendInvitationEmail(challengeID, originalInvitation.UserID, originalInvitation.Email); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *ChallengeService) ValidateUserCanReadChallenge(challengeId, userId int64) (*model.Challenge, error) {
 	challengeAndUser, err := validateChallengeBelongsToUser(challengeId, userId, s.db)
 
@@ -238,54 +332,155 @@ func (s *ChallengeService) StartChallenge(ctx context.Context, req *pb.StartChal
 		return nil, err
 	}
 
-	if challenge.Status != model.ChallengeStatusDraft {
+	if challenge.Status != model.ChallengeStatusDraft && challenge.Status != model.ChallengeStatusScheduled {
 		return nil, status.Error(400, "Cannot start started or finished challenge")
 	}
 
-	today := time.Now().Truncate(24 * time.Hour)
-
-	challenge.StartDate = today
-	challenge.EndDate = today.AddDate(0, 0, int(challenge.Days))
-	challenge.Status = model.ChallengeStatusStarted
+	today, err := s.userToday(req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
 
 	err = s.db.Transaction(func(tx *gorm.DB) error {
-		if err := s.db.WithContext(context.Background()).Save(&challenge).Error; err != nil {
+		if err := validateTasksFallOnChallenge(tx, challenge.ID, today, today.AddDate(0, 0, int(challenge.Days))); err != nil {
 			return err
 		}
 
-		participants := make([]model.ChallengeAndUser, 0)
+		return s.startChallenge(tx, challenge, today)
+	})
 
-		if err := s.db.Find(&participants, "challenge_id = ?", challenge.ID).Error; err != nil {
-			return err
-		}
+	if err != nil {
+		return nil, err
+	}
 
-		participantIds := make([]int64, 0)
-		for _, participant := range participants {
-			participantIds = append(participantIds, participant.UserID)
-		}
+	return toPbChallenge(challenge), nil
+}
 
-		if err := s.TaskSvs.createTaskAndStatusesForChallenge(tx, challenge, participantIds); err != nil {
-			return err
+// startChallenge starts the challenge on the given date and creates the task statuses of all its participants.
+func (s *ChallengeService) startChallenge(tx *gorm.DB, challenge *model.Challenge, startDate time.Time) error {
+	challenge.StartDate = startDate
+	challenge.EndDate = startDate.AddDate(0, 0, int(challenge.Days))
+	challenge.Status = model.ChallengeStatusStarted
+
+	if err := tx.WithContext(context.Background()).Save(&challenge).Error; err != nil {
+		return err
+	}
+
+	participantIds, err := getParticipantIds(tx, challenge.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.TaskSvs.createTaskAndStatusesForChallenge(tx, challenge, participantIds)
+}
+
+// getParticipantIds returns the ids of every user of the challenge, the owner included.
+func getParticipantIds(tx *gorm.DB, challengeId int64) ([]int64, error) {
+	participantIds := make([]int64, 0)
+
+	if err := tx.Model(&model.ChallengeAndUser{}).Where("challenge_id = ?", challengeId).Pluck("user_id", &participantIds).Error; err != nil {
+		return nil, err
+	}
+
+	return participantIds, nil
+}
+
+// getParticipantIdsByToday groups the ids of every user of the challenge by the current day in their time zone.
+func (s *ChallengeService) getParticipantIdsByToday(tx *gorm.DB, challengeId int64) (map[time.Time][]int64, error) {
+	var challengeAndUsers []model.ChallengeAndUser
+
+	if err := tx.Where("challenge_id = ?", challengeId).Find(&challengeAndUsers).Error; err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	participantIds := make(map[time.Time][]int64)
+
+	for _, challengeAndUser := range challengeAndUsers {
+		loc, err := loadTimeZone(challengeAndUser.TimeZone)
+		if err != nil {
+			return nil, err
 		}
 
-		return nil
-	})
+		today := calendarDay(now, loc)
+		participantIds[today] = append(participantIds[today], challengeAndUser.UserID)
+	}
+
+	return participantIds, nil
+}
 
+func (s *ChallengeService) ScheduleChallenge(ctx context.Context, req *pb.ScheduleChallengeRequest) (*pb.Challenge, error) {
+	challenge, err := s.ValidateChallengeOwnedByUser(req.ChallengeId, req.UserId)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := &pb.Challenge{
-		Id:          challenge.ID,
-		Title:       challenge.Title,
-		Description: challenge.Description,
-		StartDate:   timestamppb.New(challenge.StartDate),
-		EndDate:     timestamppb.New(challenge.EndDate),
-		Status:      challenge.Status,
-		Days:        challenge.Days,
+	if err := s.validateScheduleChallengeRequest(req, challenge); err != nil {
+		return nil, err
 	}
 
-	return resp, nil
+	startDate := req.StartDate.AsTime().Truncate(24 * time.Hour)
+
+	challenge.StartDate = startDate
+	challenge.EndDate = startDate.AddDate(0, 0, int(challenge.Days))
+	challenge.Status = model.ChallengeStatusScheduled
+
+	if err := validateTasksFallOnChallenge(s.db, challenge.ID, challenge.StartDate, challenge.EndDate); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WithContext(context.Background()).Save(&challenge).Error; err != nil {
+		return nil, err
+	}
+
+	return toPbChallenge(challenge), nil
+}
+
+func (s *ChallengeService) validateScheduleChallengeRequest(req *pb.ScheduleChallengeRequest, challenge *model.Challenge) error {
+	if challenge.Status != model.ChallengeStatusDraft && challenge.Status != model.ChallengeStatusScheduled {
+		return status.Error(400, "Cannot schedule started or finished challenge")
+	}
+
+	if req.StartDate == nil {
+		return status.Error(400, "Start date is required")
+	}
+
+	today, err := s.userToday(req.ChallengeId, req.UserId)
+	if err != nil {
+		return err
+	}
+
+	if !req.StartDate.AsTime().Truncate(24 * time.Hour).After(today) {
+		return status.Error(400, "Start date should be in the future")
+	}
+
+	return nil
+}
+
+// StartScheduledChallenges starts every scheduled challenge whose start date has come.
+func (s *ChallengeService) StartScheduledChallenges() error {
+	// Challenges start as soon as the start date comes in any time zone.
+	today := calendarDay(s.clock.Now(), earliestTimeZone)
+
+	var challenges []model.Challenge
+
+	if err := s.db.WithContext(context.Background()).Where("status = ? AND start_date <= ?", model.ChallengeStatusScheduled, today).Find(&challenges).Error; err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for i := range challenges {
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			return s.startChallenge(tx, &challenges[i], challenges[i].StartDate)
+		})
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("start challenge %d: %w", challenges[i].ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 func (s *ChallengeService) FinishChallenge(ctx context.Context, req *pb.FinishChallengeRequest) (*pb.Challenge, error) {
@@ -294,27 +489,178 @@ func (s *ChallengeService) FinishChallenge(ctx context.Context, req *pb.FinishCh
 		return nil, err
 	}
 
-	if challenge.Status != model.ChallengeStatusStarted {
+	if challenge.Status != model.ChallengeStatusStarted && challenge.Status != model.ChallengeStatusPaused {
 		return nil, status.Error(400, "Cannot finish draft or finished challenge")
 	}
 
+	today, err := s.userToday(req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		return s.finishChallenge(tx, challenge, today)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toPbChallenge(challenge), nil
+}
+
+// FinishExpiredChallenges finishes every started challenge whose end date and grace period have already passed.
+func (s *ChallengeService) FinishExpiredChallenges() error {
+	// Challenges finish only once the grace period after the end date has passed in every time zone,
+	// so that participants can still update the last days.
+	today := calendarDay(s.clock.Now(), latestTimeZone)
+
+	var challenges []model.Challenge
+
+	err := s.db.WithContext(context.Background()).
+		Where("status = ? AND end_date + make_interval(days => COALESCE(grace_days, ?)) <= ?", model.ChallengeStatusStarted, s.defaultGraceDays, today).
+		Find(&challenges).Error
+
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for i := range challenges {
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			return s.finishChallenge(tx, &challenges[i], today)
+		})
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("finish challenge %d: %w", challenges[i].ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// finishChallenge marks the challenge as finished and closes every task status that was left
+// untouched on a day before today.
+func (s *ChallengeService) finishChallenge(tx *gorm.DB, challenge *model.Challenge, today time.Time) error {
 	challenge.Status = model.ChallengeStatusFinished
+	challenge.PausedAt = nil
 
-	if err := s.db.WithContext(context.Background()).Save(&challenge).Error; err != nil {
+	if err := tx.WithContext(context.Background()).Save(&challenge).Error; err != nil {
+		return err
+	}
+
+	if err := s.TaskSvs.markMissedTaskStatuses(tx, challenge.ID, today); err != nil {
+		return err
+	}
+
+	if challenge.RecurrenceIntervalDays == 0 {
+		return nil
+	}
+
+	return s.startNextIteration(tx, challenge, today)
+}
+
+// PauseChallenge stops the challenge on today. The statuses still ahead of every participant are removed,
+// so that none of the paused days count.
+func (s *ChallengeService) PauseChallenge(ctx context.Context, req *pb.PauseChallengeRequest) (*pb.Challenge, error) {
+	challenge, err := s.ValidateChallengeOwnedByUser(req.ChallengeId, req.UserId)
+	if err != nil {
 		return nil, err
 	}
 
-	resp := &pb.Challenge{
-		Id:          challenge.ID,
-		Title:       challenge.Title,
-		Description: challenge.Description,
-		StartDate:   timestamppb.New(challenge.StartDate),
-		EndDate:     timestamppb.New(challenge.EndDate),
-		Status:      challenge.Status,
-		Days:        challenge.Days,
+	if challenge.Status != model.ChallengeStatusStarted {
+		return nil, status.Error(400, "Cannot pause not started or finished challenge")
 	}
 
-	return resp, nil
+	today, err := s.userToday(req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge.Status = model.ChallengeStatusPaused
+	challenge.PausedAt = &today
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.TaskSvs.deleteRemainingTaskStatuses(tx, challenge.ID, challengeTaskIds(tx, challenge.ID)); err != nil {
+			return err
+		}
+
+		if err := s.TaskSvs.refreshChallengeStreaks(tx, challenge.ID); err != nil {
+			return err
+		}
+
+		return tx.WithContext(context.Background()).Save(&challenge).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toPbChallenge(challenge), nil
+}
+
+// ResumeChallenge restarts a paused challenge. The end date is pushed out by the number of paused
+// days and the statuses of the remaining days are generated again from today on.
+func (s *ChallengeService) ResumeChallenge(ctx context.Context, req *pb.ResumeChallengeRequest) (*pb.Challenge, error) {
+	challenge, err := s.ValidateChallengeOwnedByUser(req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if challenge.Status != model.ChallengeStatusPaused {
+		return nil, status.Error(400, "Cannot resume not paused challenge")
+	}
+
+	today, err := s.userToday(req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	pausedDays := int(today.Sub(*challenge.PausedAt).Hours() / 24)
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.resumeRemainingDays(tx, challenge, pausedDays); err != nil {
+			return err
+		}
+
+		challenge.Status = model.ChallengeStatusStarted
+		challenge.PausedAt = nil
+
+		return tx.WithContext(context.Background()).Save(&challenge).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toPbChallenge(challenge), nil
+}
+
+// resumeRemainingDays shifts the end of the challenge by the paused days and generates the statuses of every
+// participant from their today on, those of the remaining days having been removed on pause.
+func (s *ChallengeService) resumeRemainingDays(tx *gorm.DB, challenge *model.Challenge, pausedDays int) error {
+	if pausedDays > 0 {
+		challenge.EndDate = challenge.EndDate.AddDate(0, 0, pausedDays)
+	}
+
+	tasks, err := getTasksByChallengeId(tx, challenge.ID)
+	if err != nil {
+		return err
+	}
+
+	participantIdsByToday, err := s.getParticipantIdsByToday(tx, challenge.ID)
+	if err != nil {
+		return err
+	}
+
+	for today, participantIds := range participantIdsByToday {
+		if err := createTaskAndStatuses(tx, buildTaskAndStatuses(tasks, participantIds, challenge.StartDate, today, challenge.EndDate)); err != nil {
+			return err
+		}
+	}
+
+	return s.TaskSvs.refreshChallengeStreaks(tx, challenge.ID)
 }
 
 func (s *ChallengeService) AddUserToChallenge(ctx context.Context, req *pb.AddUserToChallengeRequest) (*pb.AddUserToChallengeResponse, error) {
@@ -329,6 +675,7 @@ func (s *ChallengeService) AddUserToChallenge(ctx context.Context, req *pb.AddUs
 			ChallengeID: req.ChallengeId,
 			UserID:      req.UserToAddId,
 			Status:      model.ChallengeInvitationStatusPending,
+			Email:       req.Email,
 		}
 
 		if err := s.db.WithContext(context.Background()).Create(&challengeInvitation).Error; err != nil {
@@ -352,7 +699,7 @@ func (s *ChallengeService) AddUserToChallenge(ctx context.Context, req *pb.AddUs
 }
 
 func (s *ChallengeService) sendInvitationEmail(challengeID, userID int64, email string) error {
-	token, err := GenerateJWT(userID, challengeID)
+	token, err := GenerateJWT(s.clock, userID, challengeID)
 
 	if err != nil {
 		return err
@@ -367,6 +714,12 @@ func (s *ChallengeService) sendInvitationEmail(challengeID, userID int64, email
 	return s.GenericEmailPublisher.Publish(message)
 }
 
+// isLateJoin reports whether it is too late to join the challenge on the given day. Users can join until the end
+// of the start day in their time zone.
+func isLateJoin(challenge *model.Challenge, today time.Time) bool {
+	return (challenge.Status == model.ChallengeStatusStarted || challenge.Status == model.ChallengeStatusPaused) && today.After(challenge.StartDate)
+}
+
 func (s *ChallengeService) validateAddUserToChallengeRequest(req *pb.AddUserToChallengeRequest) error {
 	challenge, err := s.ValidateChallengeOwnedByUser(req.ChallengeId, req.UserId)
 
@@ -378,7 +731,12 @@ func (s *ChallengeService) validateAddUserToChallengeRequest(req *pb.AddUserToCh
 		return status.Error(400, "Cannot add user to finished challenge")
 	}
 
-	if today := time.Now().Truncate(24 * time.Hour); challenge.Status == model.ChallengeStatusStarted && today.After(challenge.StartDate) {
+	today, err := s.userToday(req.ChallengeId, req.UserId)
+	if err != nil {
+		return err
+	}
+
+	if isLateJoin(challenge, today) {
 		return status.Error(400, "Cannot add user after one day from the start date")
 	}
 
@@ -399,14 +757,39 @@ func (s *ChallengeService) validateUserSubscribedToChallenge(challengeID, userID
 	return challengeAndUser, nil
 }
 
+// userToday returns the current day in the time zone of the challenge participant.
+func (s *ChallengeService) userToday(challengeID, userID int64) (time.Time, error) {
+	now, err := s.userNow(challengeID, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return calendarDay(now, now.Location()), nil
+}
+
+// userNow returns the current time in the time zone of the participant.
+func (s *ChallengeService) userNow(challengeID, userID int64) (time.Time, error) {
+	challengeAndUser, err := s.validateUserSubscribedToChallenge(challengeID, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	loc, err := loadTimeZone(challengeAndUser.TimeZone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return s.clock.Now().In(loc), nil
+}
+
 func (s *ChallengeService) SubscribeToChallenge(ctx context.Context, req *pb.SubscribeToChallengeRequest) (*pb.Challenge, error) {
-	claims, err := VerifyChallengeInvitationJWT(req.Token)
+	claims, err := VerifyChallengeInvitationJWT(s.clock, req.Token)
 
 	if err != nil {
 		return nil, status.Error(400, "Invalid token")
 	}
 
-	if err := s.validateSubscribeToChallengeRequest(claims); err != nil {
+	if err := s.validateSubscribeToChallengeRequest(claims, req.TimeZone); err != nil {
 		return nil, err
 	}
 
@@ -414,6 +797,7 @@ func (s *ChallengeService) SubscribeToChallenge(ctx context.Context, req *pb.Sub
 		ChallengeID: claims.ChallengeID,
 		UserID:      claims.UserID,
 		UserRole:    model.ChallengeAndUserParticipantRole,
+		TimeZone:    timeZoneOrUTC(req.TimeZone),
 	}
 
 	err = s.db.Transaction(func(tx *gorm.DB) error {
@@ -433,6 +817,11 @@ func (s *ChallengeService) SubscribeToChallenge(ctx context.Context, req *pb.Sub
 			return err
 		}
 
+		// Draft and scheduled challenges get the task statuses of all participants once they start.
+		if challengeInvitation.Challenge.Status != model.ChallengeStatusStarted {
+			return nil
+		}
+
 		if err := s.TaskSvs.createTaskAndStatusesForChallenge(tx, &challengeInvitation.Challenge, []int64{claims.UserID}); err != nil {
 			return err
 		}
@@ -447,7 +836,7 @@ func (s *ChallengeService) SubscribeToChallenge(ctx context.Context, req *pb.Sub
 	return s.GetChallengeById(ctx, &pb.GetChallengeRequest{Id: claims.ChallengeID, UserId: claims.UserID})
 }
 
-func (s *ChallengeService) validateSubscribeToChallengeRequest(claims *ChallengeInvitationClaims) error {
+func (s *ChallengeService) validateSubscribeToChallengeRequest(claims *ChallengeInvitationClaims, timeZone string) error {
 	if _, err := s.validateUserSubscribedToChallenge(claims.ChallengeID, claims.UserID); err == nil {
 		return status.Error(400, "User already added to challenge")
 	}
@@ -462,9 +851,16 @@ func (s *ChallengeService) validateSubscribeToChallengeRequest(claims *Challenge
 		return status.Error(400, "Cannot subscribe to finished challenge")
 	}
 
-	today := time.Now().Truncate(24 * time.Hour)
+	loc, err := loadTimeZone(timeZone)
+	if err != nil {
+		return err
+	}
+
+	today := calendarDay(s.clock.Now(), loc)
+
+	challenge := challengeInvitation.Challenge
 
-	if challengeInvitation.Challenge.Status == model.ChallengeStatusStarted && today.After(challengeInvitation.Challenge.StartDate) {
+	if isLateJoin(&challenge, today) {
 		return status.Error(400, "Cannot subscribe after one day from the start date")
 	}
 
@@ -500,3 +896,47 @@ func (s *ChallengeService) validateUnsubscribeFromChallengeRequest(req *pb.Unsub
 
 	return nil
 }
+
+func (s *ChallengeService) UpdateParticipantTimeZone(ctx context.Context, req *pb.UpdateParticipantTimeZoneRequest) (*emptypb.Empty, error) {
+	challengeAndUser, err := s.validateUserSubscribedToChallenge(req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := loadTimeZone(req.TimeZone); err != nil {
+		return nil, err
+	}
+
+	challengeAndUser.TimeZone = timeZoneOrUTC(req.TimeZone)
+
+	if err := s.db.WithContext(context.Background()).Save(&challengeAndUser).Error; err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func toPbChallenge(challenge *model.Challenge) *pb.Challenge {
+	resp := &pb.Challenge{
+		Id:                     challenge.ID,
+		Title:                  challenge.Title,
+		Description:            challenge.Description,
+		Status:                 challenge.Status,
+		Days:                   challenge.Days,
+		RecurrenceIntervalDays: challenge.RecurrenceIntervalDays,
+		SeriesId:               challenge.SeriesID,
+		Iteration:              challenge.Iteration,
+		GraceDays:              challenge.GraceDays,
+	}
+
+	if !challenge.StartDate.IsZero() {
+		resp.StartDate = timestamppb.New(challenge.StartDate)
+		resp.EndDate = timestamppb.New(challenge.EndDate)
+	}
+
+	if challenge.PausedAt != nil {
+		resp.PausedAt = timestamppb.New(*challenge.PausedAt)
+	}
+
+	return resp
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"ryg-task-service/clock"
+	pb "ryg-task-service/gen_proto/task_service"
+	"ryg-task-service/model"
+	"testing"
+	"time"
+)
+
+func TestStartChallenge(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 20, 0, 0, 0, time.UTC)
+	scheduledDate := date(2026, time.March, 12)
+
+	tests := []struct {
+		name     string
+		timeZone string
+		// scheduled challenges are started by StartScheduledChallenges once elapsed has passed,
+		// the others are started right away.
+		scheduled     bool
+		elapsed       time.Duration
+		wantStatus    string
+		wantStartDate time.Time
+	}{
+		{
+			name:          "started today",
+			wantStatus:    model.ChallengeStatusStarted,
+			wantStartDate: date(2026, time.March, 10),
+		},
+		{
+			name:          "started today in the time zone of the owner",
+			timeZone:      "Pacific/Auckland",
+			wantStatus:    model.ChallengeStatusStarted,
+			wantStartDate: date(2026, time.March, 11),
+		},
+		{
+			name:          "scheduled before the start date",
+			scheduled:     true,
+			wantStatus:    model.ChallengeStatusScheduled,
+			wantStartDate: scheduledDate,
+		},
+		{
+			name:          "scheduled once the start date comes in the earliest time zone",
+			scheduled:     true,
+			elapsed:       24 * time.Hour,
+			wantStatus:    model.ChallengeStatusStarted,
+			wantStartDate: scheduledDate,
+		},
+		{
+			name:          "scheduled after the start date",
+			scheduled:     true,
+			elapsed:       5 * 24 * time.Hour,
+			wantStatus:    model.ChallengeStatusStarted,
+			wantStartDate: scheduledDate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewFakeClock(now)
+			challengeService, _ := newTestServices(t, clk)
+
+			challenge := createTestChallenge(t, challengeService, 5, tt.timeZone)
+
+			if tt.scheduled {
+				_, err := challengeService.ScheduleChallenge(context.Background(), &pb.ScheduleChallengeRequest{
+					ChallengeId: challenge.Id,
+					UserId:      testOwnerId,
+					StartDate:   timestamppb.New(scheduledDate),
+				})
+
+				if err != nil {
+					t.Fatalf("schedule challenge: %v", err)
+				}
+
+				clk.Advance(tt.elapsed)
+
+				if err := challengeService.StartScheduledChallenges(); err != nil {
+					t.Fatalf("start scheduled challenges: %v", err)
+				}
+			} else {
+				startTestChallenge(t, challengeService, challenge.Id)
+			}
+
+			got, err := challengeService.GetChallengeById(context.Background(), &pb.GetChallengeRequest{Id: challenge.Id, UserId: testOwnerId})
+			if err != nil {
+				t.Fatalf("get challenge: %v", err)
+			}
+
+			if got.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", got.Status, tt.wantStatus)
+			}
+
+			if !got.StartDate.AsTime().Equal(tt.wantStartDate) {
+				t.Errorf("start date = %v, want %v", got.StartDate.AsTime(), tt.wantStartDate)
+			}
+
+			if wantEndDate := tt.wantStartDate.AddDate(0, 0, 5); !got.EndDate.AsTime().Equal(wantEndDate) {
+				t.Errorf("end date = %v, want %v", got.EndDate.AsTime(), wantEndDate)
+			}
+		})
+	}
+}
+
+func TestScheduleChallengeRequiresFutureStartDate(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		startDate time.Time
+		wantErr   string
+	}{
+		{name: "yesterday", startDate: date(2026, time.March, 9), wantErr: "Start date should be in the future"},
+		{name: "today", startDate: date(2026, time.March, 10), wantErr: "Start date should be in the future"},
+		{name: "tomorrow", startDate: date(2026, time.March, 11)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			challengeService, _ := newTestServices(t, clock.NewFakeClock(now))
+
+			challenge := createTestChallenge(t, challengeService, 5, "")
+
+			_, err := challengeService.ScheduleChallenge(context.Background(), &pb.ScheduleChallengeRequest{
+				ChallengeId: challenge.Id,
+				UserId:      testOwnerId,
+				StartDate:   timestamppb.New(tt.startDate),
+			})
+
+			if got := errorMessage(err); got != tt.wantErr {
+				t.Errorf("error = %q, want %q", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+type lateJoinTest struct {
+	name    string
+	start   bool
+	pause   bool
+	elapsed time.Duration
+	// timeZone is the time zone the invited user subscribes from.
+	timeZone string
+	// wantRejected tells whether joining is rejected for being too late.
+	wantRejected bool
+}
+
+// lateJoinTests returns the challenges users join, elapsed after the challenge is created in UTC
+// at 23:00 on the 10th of March.
+func lateJoinTests() []lateJoinTest {
+	return []lateJoinTest{
+		{name: "draft challenge", elapsed: 3 * 24 * time.Hour},
+		{name: "on the start day", start: true, elapsed: 30 * time.Minute},
+		{name: "the day after the start day", start: true, elapsed: 2 * time.Hour, wantRejected: true},
+		{name: "paused after the start day", start: true, pause: true, elapsed: 24 * time.Hour, wantRejected: true},
+	}
+}
+
+func TestAddUserToChallengeLateJoin(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 23, 0, 0, 0, time.UTC)
+
+	for _, tt := range lateJoinTests() {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewFakeClock(now)
+			challengeService, _ := newTestServices(t, clk)
+
+			challenge := createTestChallenge(t, challengeService, 5, "")
+
+			if tt.start {
+				startTestChallenge(t, challengeService, challenge.Id)
+			}
+
+			if tt.pause {
+				pauseTestChallenge(t, challengeService, challenge.Id)
+			}
+
+			clk.Advance(tt.elapsed)
+
+			_, err := challengeService.AddUserToChallenge(context.Background(), &pb.AddUserToChallengeRequest{
+				UserId:      testOwnerId,
+				ChallengeId: challenge.Id,
+				UserToAddId: testParticipantId,
+				Email:       "participant@example.com",
+			})
+
+			wantErr := ""
+			if tt.wantRejected {
+				wantErr = "Cannot add user after one day from the start date"
+			}
+
+			if got := errorMessage(err); got != wantErr {
+				t.Errorf("error = %q, want %q", got, wantErr)
+			}
+		})
+	}
+}
+
+func TestSubscribeToChallengeLateJoin(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 23, 0, 0, 0, time.UTC)
+
+	tests := append(lateJoinTests(), lateJoinTest{
+		name:     "on the start day in the time zone of the participant",
+		start:    true,
+		elapsed:  2 * time.Hour,
+		timeZone: "America/New_York",
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewFakeClock(now)
+			challengeService, _ := newTestServices(t, clk)
+
+			challenge := createTestChallenge(t, challengeService, 5, "")
+
+			_, err := challengeService.AddUserToChallenge(context.Background(), &pb.AddUserToChallengeRequest{
+				UserId:      testOwnerId,
+				ChallengeId: challenge.Id,
+				UserToAddId: testParticipantId,
+				Email:       "participant@example.com",
+			})
+
+			if err != nil {
+				t.Fatalf("add user to challenge: %v", err)
+			}
+
+			if tt.start {
+				startTestChallenge(t, challengeService, challenge.Id)
+			}
+
+			if tt.pause {
+				pauseTestChallenge(t, challengeService, challenge.Id)
+			}
+
+			clk.Advance(tt.elapsed)
+
+			// The invitation is sent again late, so that only the late-join rule can reject it.
+			token, err := GenerateJWT(clk, testParticipantId, challenge.Id)
+			if err != nil {
+				t.Fatalf("generate token: %v", err)
+			}
+
+			_, err = challengeService.SubscribeToChallenge(context.Background(), &pb.SubscribeToChallengeRequest{
+				Token:    token,
+				TimeZone: tt.timeZone,
+			})
+
+			wantErr := ""
+			if tt.wantRejected {
+				wantErr = "Cannot subscribe after one day from the start date"
+			}
+
+			if got := errorMessage(err); got != wantErr {
+				t.Errorf("error = %q, want %q", got, wantErr)
+			}
+		})
+	}
+}
+
+func TestPauseChallengeRemovesRemainingDays(t *testing.T) {
+	// The five day challenge starts on the 10th of March and is paused on the 12th.
+	clk := clock.NewFakeClock(time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC))
+	challengeService, taskService := newTestServices(t, clk)
+
+	challenge := createTestChallenge(t, challengeService, 5, "")
+
+	task, err := taskService.CreateTask(context.Background(), &pb.CreateTaskRequest{
+		Title:       "Task",
+		ChallengeId: challenge.Id,
+		UserId:      testOwnerId,
+		WeekDays:    int32(model.AllWeekDays),
+	})
+
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	startTestChallenge(t, challengeService, challenge.Id)
+
+	clk.Advance(2 * 24 * time.Hour)
+	pauseTestChallenge(t, challengeService, challenge.Id)
+
+	wantDates := []time.Time{date(2026, time.March, 10), date(2026, time.March, 11)}
+
+	if dates := taskStatusDates(t, taskService, task.Id, testOwnerId); !sameDates(dates, wantDates) {
+		t.Errorf("task statuses on %v after pausing, want %v", dates, wantDates)
+	}
+
+	clk.Advance(24 * time.Hour)
+
+	_, err = challengeService.ResumeChallenge(context.Background(), &pb.ResumeChallengeRequest{ChallengeId: challenge.Id, UserId: testOwnerId})
+	if err != nil {
+		t.Fatalf("resume challenge: %v", err)
+	}
+
+	wantDates = append(wantDates, date(2026, time.March, 13), date(2026, time.March, 14), date(2026, time.March, 15))
+
+	if dates := taskStatusDates(t, taskService, task.Id, testOwnerId); !sameDates(dates, wantDates) {
+		t.Errorf("task statuses on %v after resuming, want %v", dates, wantDates)
+	}
+}
+
+func TestIsLateJoin(t *testing.T) {
+	startDate := date(2026, time.March, 10)
+
+	tests := []struct {
+		name   string
+		status string
+		today  time.Time
+		want   bool
+	}{
+		{name: "draft challenge", status: model.ChallengeStatusDraft, today: date(2026, time.March, 20)},
+		{name: "scheduled challenge", status: model.ChallengeStatusScheduled, today: date(2026, time.March, 20)},
+		{name: "on the start day", status: model.ChallengeStatusStarted, today: startDate},
+		{name: "the day after the start day", status: model.ChallengeStatusStarted, today: date(2026, time.March, 11), want: true},
+		{name: "paused after the start day", status: model.ChallengeStatusPaused, today: date(2026, time.March, 11), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			challenge := &model.Challenge{Status: tt.status, StartDate: startDate}
+
+			if got := isLateJoin(challenge, tt.today); got != tt.want {
+				t.Errorf("isLateJoin = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	pb "ryg-task-service/gen_proto/task_service"
+	"ryg-task-service/model"
+	"time"
+)
+
+type checklistCount struct {
+	Checked int64
+	Total   int64
+}
+
+// GetChecklistItems returns the checklist of the task. When a date is given, the items tell whether
+// the user checked them on that day.
+func (s *TaskService) GetChecklistItems(ctx context.Context, req *pb.GetChecklistItemsRequest) (*pb.ChecklistItemList, error) {
+	task, err := s.validateUserCanReadTask(req.TaskId, req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := getChecklistItems(s.db, task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	checkedItemIds := make(map[int64]bool)
+
+	if req.Date != nil {
+		checkedItemIds, err = getCheckedItemIds(s.db, task.ID, req.UserId, req.Date.AsTime())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &pb.ChecklistItemList{
+		Items: make([]*pb.ChecklistItem, 0, len(items)),
+	}
+
+	for _, item := range items {
+		pbItem := toPbChecklistItem(&item)
+		pbItem.Checked = checkedItemIds[item.ID]
+		resp.Items = append(resp.Items, pbItem)
+	}
+
+	return resp, nil
+}
+
+func (s *TaskService) AddChecklistItem(ctx context.Context, req *pb.AddChecklistItemRequest) (*pb.ChecklistItem, error) {
+	task, err := s.validateChecklistOwnedByUser(req.TaskId, req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title == "" {
+		return nil, status.Error(400, "Title is required")
+	}
+
+	var item model.ChecklistItem
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var maxPosition int32
+
+		if err := tx.Model(&model.ChecklistItem{}).Where("task_id = ?", task.ID).Select("COALESCE(MAX(position), 0)").Scan(&maxPosition).Error; err != nil {
+			return err
+		}
+
+		item = model.ChecklistItem{
+			TaskID:   task.ID,
+			Title:    req.Title,
+			Position: maxPosition + 1,
+		}
+
+		return tx.WithContext(context.Background()).Create(&item).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toPbChecklistItem(&item), nil
+}
+
+func (s *TaskService) UpdateChecklistItem(ctx context.Context, req *pb.UpdateChecklistItemRequest) (*pb.ChecklistItem, error) {
+	task, err := s.validateChecklistOwnedByUser(req.TaskId, req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title == "" {
+		return nil, status.Error(400, "Title is required")
+	}
+
+	var item model.ChecklistItem
+
+	if err := s.db.First(&item, "id = ? AND task_id = ?", req.Id, task.ID).Error; err != nil {
+		return nil, status.Error(404, "Checklist item not found")
+	}
+
+	item.Title = req.Title
+
+	if err := s.db.WithContext(context.Background()).Save(&item).Error; err != nil {
+		return nil, err
+	}
+
+	return toPbChecklistItem(&item), nil
+}
+
+// DeleteChecklistItem removes the item together with its daily states. Statuses already derived from
+// the checklist are kept.
+func (s *TaskService) DeleteChecklistItem(ctx context.Context, req *pb.DeleteChecklistItemRequest) (*emptypb.Empty, error) {
+	task, err := s.validateChecklistOwnedByUser(req.TaskId, req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.db.WithContext(context.Background()).Delete(&model.ChecklistItem{}, "id = ? AND task_id = ?", req.Id, task.ID)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, status.Error(404, "Checklist item not found")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ToggleChecklistItem checks or unchecks an item for the day and derives the status of the task from its checklist.
+// The task is completed once all of its items are checked.
+func (s *TaskService) ToggleChecklistItem(ctx context.Context, req *pb.ToggleChecklistItemRequest) (*pb.TaskWithStatus, error) {
+	task, err := s.validateUserCanReadTask(req.TaskId, req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.ArchivedAt != nil {
+		return nil, status.Error(400, "Cannot update status of archived task")
+	}
+
+	if err := validateTaskAssignedToUser(task, req.UserId); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateTaskStatusDate(req.ChallengeId, req.UserId, req.Date); err != nil {
+		return nil, err
+	}
+
+	var taskAndStatus model.TaskAndStatus
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var item model.ChecklistItem
+
+		if err := tx.First(&item, "id = ? AND task_id = ?", req.Id, task.ID).Error; err != nil {
+			return status.Error(404, "Checklist item not found")
+		}
+
+		if err := tx.WithContext(context.Background()).First(&taskAndStatus, "task_id = ? AND date = ? AND user_id = ?", req.TaskId, req.Date.AsTime(), req.UserId).Error; err != nil {
+			return err
+		}
+
+		state := model.ChecklistItemState{
+			UserID:          req.UserId,
+			ChecklistItemID: item.ID,
+			Date:            req.Date.AsTime(),
+			Checked:         req.Checked,
+		}
+
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&state).Error; err != nil {
+			return err
+		}
+
+		var count checklistCount
+
+		err := tx.Model(&model.ChecklistItem{}).
+			Select("COUNT(*) FILTER (WHERE checklist_item_states.checked) AS checked, COUNT(*) AS total").
+			Joins("LEFT JOIN checklist_item_states ON checklist_item_states.checklist_item_id = checklist_items.id AND checklist_item_states.user_id = ? AND checklist_item_states.date = ?", req.UserId, req.Date.AsTime()).
+			Where("checklist_items.task_id = ?", task.ID).
+			Scan(&count).Error
+
+		if err != nil {
+			return err
+		}
+
+		newStatus := model.StatusForChecklist(count.Checked, count.Total)
+
+		if err := s.applyTaskWindow(&taskAndStatus, task, newStatus); err != nil {
+			return err
+		}
+
+		return s.saveTaskStatus(tx, task.ChallengeID, &taskAndStatus, newStatus, req.UserId)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	taskAndStatus.Task = *task
+
+	return toPbTaskWithStatus(&taskAndStatus), nil
+}
+
+// validateChecklistOwnedByUser checks that the user may change the checklist of the task.
+func (s *TaskService) validateChecklistOwnedByUser(taskId, challengeId, userId int64) (*model.Task, error) {
+	task, err := s.validateTaskOwnedByUser(taskId, challengeId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := s.ChallengeSvs.ValidateChallengeOwnedByUser(challengeId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if challenge.Status == model.ChallengeStatusFinished {
+		return nil, status.Error(400, "Cannot update task for finished challenge")
+	}
+
+	if task.ArchivedAt != nil {
+		return nil, status.Error(400, "Cannot update archived task")
+	}
+
+	if task.IsQuantitative() {
+		return nil, status.Error(400, "Quantitative task cannot have a checklist")
+	}
+
+	return task, nil
+}
+
+func getChecklistItems(tx *gorm.DB, taskId int64) ([]model.ChecklistItem, error) {
+	var items []model.ChecklistItem
+
+	if err := tx.WithContext(context.Background()).Where("task_id = ?", taskId).Order("position, id").Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func getCheckedItemIds(tx *gorm.DB, taskId, userId int64, date time.Time) (map[int64]bool, error) {
+	var itemIds []int64
+
+	err := tx.Model(&model.ChecklistItemState{}).
+		Joins("JOIN checklist_items ON checklist_items.id = checklist_item_states.checklist_item_id").
+		Where("checklist_items.task_id = ? AND checklist_item_states.user_id = ? AND checklist_item_states.date = ? AND checklist_item_states.checked", taskId, userId, date).
+		Pluck("checklist_item_states.checklist_item_id", &itemIds).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	checked := make(map[int64]bool, len(itemIds))
+	for _, itemId := range itemIds {
+		checked[itemId] = true
+	}
+
+	return checked, nil
+}
+
+// getChecklistTitles returns the titles of the checklist items of every task, in their order.
+func getChecklistTitles(tx *gorm.DB, taskIds []int64) (map[int64][]string, error) {
+	var items []model.ChecklistItem
+
+	if err := tx.Where("task_id IN ?", taskIds).Order("position, id").Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	titles := make(map[int64][]string)
+	for _, item := range items {
+		titles[item.TaskID] = append(titles[item.TaskID], item.Title)
+	}
+
+	return titles, nil
+}
+
+// copyChecklistItems copies the checklist of every task to the task at the same index of the copies.
+func copyChecklistItems(tx *gorm.DB, fromTaskIds []int64, toTasks []model.Task) error {
+	toTaskIds := make(map[int64]int64, len(fromTaskIds))
+	for i, taskId := range fromTaskIds {
+		toTaskIds[taskId] = toTasks[i].ID
+	}
+
+	var items []model.ChecklistItem
+
+	if err := tx.Where("task_id IN ?", fromTaskIds).Order("position, id").Find(&items).Error; err != nil {
+		return err
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	for i := range items {
+		items[i].ID = 0
+		items[i].TaskID = toTaskIds[items[i].TaskID]
+	}
+
+	return tx.WithContext(context.Background()).Create(&items).Error
+}
+
+// checklistItemsFromTitles builds the checklist of a task created from a template.
+func checklistItemsFromTitles(taskId int64, titles []string) []model.ChecklistItem {
+	items := make([]model.ChecklistItem, 0, len(titles))
+
+	for i, title := range titles {
+		items = append(items, model.ChecklistItem{
+			TaskID:   taskId,
+			Title:    title,
+			Position: int32(i + 1),
+		})
+	}
+
+	return items
+}
+
+func toPbChecklistItem(item *model.ChecklistItem) *pb.ChecklistItem {
+	return &pb.ChecklistItem{
+		Id:       item.ID,
+		TaskId:   item.TaskID,
+		Title:    item.Title,
+		Position: item.Position,
+	}
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+	pb "ryg-task-service/gen_proto/task_service"
+	"ryg-task-service/model"
+	"time"
+)
+
+// RequestStatusCorrection asks the owner of the challenge to change a status that can no longer be updated
+// directly, because its day is outside the grace period or the challenge is paused or finished.
+func (s *TaskService) RequestStatusCorrection(ctx context.Context, req *pb.RequestStatusCorrectionRequest) (*pb.StatusCorrection, error) {
+	task, err := s.validateUserCanReadTask(req.TaskId, req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	requestedStatus, err := s.validateRequestStatusCorrectionRequest(req, task)
+	if err != nil {
+		return nil, err
+	}
+
+	correction := &model.StatusCorrection{
+		TaskID:          task.ID,
+		UserID:          req.UserId,
+		Date:            req.Date.AsTime(),
+		RequestedStatus: requestedStatus,
+		Reason:          req.Reason,
+		Status:          model.StatusCorrectionPending,
+		RequestedAt:     s.clock.Now(),
+	}
+
+	if task.IsQuantitative() {
+		correction.RequestedProgress = req.Progress
+	}
+
+	if err := s.db.WithContext(ctx).Create(correction).Error; err != nil {
+		return nil, err
+	}
+
+	return toPbStatusCorrection(correction), nil
+}
+
+// validateRequestStatusCorrectionRequest returns the requested status, which is derived from the progress for quantitative tasks.
+func (s *TaskService) validateRequestStatusCorrectionRequest(req *pb.RequestStatusCorrectionRequest, task *model.Task) (model.TaskStatus, error) {
+	if task.ArchivedAt != nil {
+		return "", status.Error(400, "Cannot update status of archived task")
+	}
+
+	if err := validateTaskAssignedToUser(task, req.UserId); err != nil {
+		return "", err
+	}
+
+	var requestedStatus model.TaskStatus
+
+	if task.IsQuantitative() {
+		if req.Progress == nil || *req.Progress < 0 {
+			return "", status.Error(400, "Progress is required and cannot be negative")
+		}
+
+		requestedStatus = task.StatusForProgress(*req.Progress)
+	} else {
+		var err error
+		if requestedStatus, err = taskStatusFromPb(req.Status); err != nil {
+			return "", err
+		}
+	}
+
+	challenge, err := s.ChallengeSvs.ValidateUserCanReadChallenge(req.ChallengeId, req.UserId)
+	if err != nil {
+		return "", err
+	}
+
+	if challenge.Status == model.ChallengeStatusDraft || challenge.Status == model.ChallengeStatusScheduled {
+		return "", status.Error(400, "Cannot correct task status for not started challenge")
+	}
+
+	today, err := s.ChallengeSvs.userToday(req.ChallengeId, req.UserId)
+	if err != nil {
+		return "", err
+	}
+
+	requestDate := req.Date.AsTime().Truncate(24 * time.Hour)
+
+	if requestDate.After(today) {
+		return "", status.Error(400, "Cannot correct task status for a future date")
+	}
+
+	if challenge.Status == model.ChallengeStatusStarted && !requestDate.Before(s.ChallengeSvs.graceStart(challenge, today)) {
+		return "", status.Error(400, "Date is within the grace period, update the status directly")
+	}
+
+	var taskAndStatus model.TaskAndStatus
+
+	if err := s.db.First(&taskAndStatus, "task_id = ? AND date = ? AND user_id = ?", task.ID, req.Date.AsTime(), req.UserId).Error; err != nil {
+		return "", status.Error(404, "Task is not scheduled for the date")
+	}
+
+	if !task.IsQuantitative() {
+		if err := validateTaskStatusTransition(taskAndStatus.Status, requestedStatus); err != nil {
+			return "", err
+		}
+	}
+
+	var pendingCorrections int64
+
+	if err := s.db.Model(&model.StatusCorrection{}).Where("task_id = ? AND date = ? AND user_id = ? AND status = ?", task.ID, req.Date.AsTime(), req.UserId, model.StatusCorrectionPending).Count(&pendingCorrections).Error; err != nil {
+		return "", err
+	}
+
+	if pendingCorrections > 0 {
+		return "", status.Error(400, "A correction is already pending for the date")
+	}
+
+	return requestedStatus, nil
+}
+
+// ApproveStatusCorrection applies the requested status. The owner approving it is recorded as the actor of the change.
+func (s *TaskService) ApproveStatusCorrection(ctx context.Context, req *pb.ReviewStatusCorrectionRequest) (*pb.TaskWithStatus, error) {
+	correction, err := s.validateReviewStatusCorrectionRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	task := correction.Task
+	var taskAndStatus model.TaskAndStatus
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&taskAndStatus, "task_id = ? AND date = ? AND user_id = ?", correction.TaskID, correction.Date, correction.UserID).Error; err != nil {
+			return err
+		}
+
+		if correction.RequestedProgress != nil {
+			taskAndStatus.Progress = *correction.RequestedProgress
+		}
+
+		if err := s.applyTaskWindow(&taskAndStatus, &task, correction.RequestedStatus); err != nil {
+			return err
+		}
+
+		if err := s.saveTaskStatus(tx, task.ChallengeID, &taskAndStatus, correction.RequestedStatus, req.UserId); err != nil {
+			return err
+		}
+
+		return s.reviewStatusCorrection(tx, correction, model.StatusCorrectionApproved, req.UserId)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	taskAndStatus.Task = task
+
+	return toPbTaskWithStatus(&taskAndStatus), nil
+}
+
+func (s *TaskService) RejectStatusCorrection(ctx context.Context, req *pb.ReviewStatusCorrectionRequest) (*pb.StatusCorrection, error) {
+	correction, err := s.validateReviewStatusCorrectionRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reviewStatusCorrection(s.db, correction, model.StatusCorrectionRejected, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return toPbStatusCorrection(correction), nil
+}
+
+func (s *TaskService) validateReviewStatusCorrectionRequest(req *pb.ReviewStatusCorrectionRequest) (*model.StatusCorrection, error) {
+	if _, err := s.ChallengeSvs.ValidateChallengeOwnedByUser(req.ChallengeId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	var correction model.StatusCorrection
+
+	if err := s.db.Preload("Task").First(&correction, req.Id).Error; err != nil {
+		return nil, err
+	}
+
+	if correction.Task.ChallengeID != req.ChallengeId {
+		return nil, status.Error(404, "Correction not found")
+	}
+
+	if correction.Status != model.StatusCorrectionPending {
+		return nil, status.Error(400, "Correction has already been reviewed")
+	}
+
+	return &correction, nil
+}
+
+func (s *TaskService) reviewStatusCorrection(tx *gorm.DB, correction *model.StatusCorrection, correctionStatus string, reviewerId int64) error {
+	now := s.clock.Now()
+
+	correction.Status = correctionStatus
+	correction.ReviewerID = &reviewerId
+	correction.ReviewedAt = &now
+
+	return tx.WithContext(context.Background()).Omit("Task").Save(correction).Error
+}
+
+// GetStatusCorrections returns the corrections of the challenge, newest first. Owners get the corrections
+// of every participant, participants only their own.
+func (s *TaskService) GetStatusCorrections(ctx context.Context, req *pb.GetStatusCorrectionsRequest) (*pb.StatusCorrectionList, error) {
+	challengeAndUser, err := validateChallengeBelongsToUser(req.ChallengeId, req.UserId, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.db.WithContext(ctx).Where("task_id IN (?)", challengeTaskIds(s.db, req.ChallengeId))
+
+	if challengeAndUser.UserRole != model.ChallengeAndUserOwnerRole {
+		query = query.Where("user_id = ?", req.UserId)
+	}
+
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+
+	var corrections []model.StatusCorrection
+
+	if err := query.Order("requested_at DESC, id DESC").Find(&corrections).Error; err != nil {
+		return nil, err
+	}
+
+	resp := &pb.StatusCorrectionList{
+		Corrections: make([]*pb.StatusCorrection, 0, len(corrections)),
+	}
+
+	for _, correction := range corrections {
+		resp.Corrections = append(resp.Corrections, toPbStatusCorrection(&correction))
+	}
+
+	return resp, nil
+}
+
+func toPbStatusCorrection(correction *model.StatusCorrection) *pb.StatusCorrection {
+	resp := &pb.StatusCorrection{
+		Id:                correction.ID,
+		TaskId:            correction.TaskID,
+		UserId:            correction.UserID,
+		Date:              timestamppb.New(correction.Date),
+		RequestedStatus:   toPbTaskStatus(correction.RequestedStatus),
+		RequestedProgress: correction.RequestedProgress,
+		Reason:            correction.Reason,
+		Status:            correction.Status,
+		ReviewerId:        correction.ReviewerID,
+		RequestedAt:       timestamppb.New(correction.RequestedAt),
+	}
+
+	if correction.ReviewedAt != nil {
+		resp.ReviewedAt = timestamppb.New(*correction.ReviewedAt)
+	}
+
+	return resp
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+var (
+	// earliestTimeZone and latestTimeZone are the first and the last time zones to enter a calendar day.
+	earliestTimeZone = time.FixedZone("UTC+14", 14*60*60)
+	latestTimeZone   = time.FixedZone("UTC-12", -12*60*60)
+)
+
+// calendarDay returns the day t falls on in loc. Like every date stored by the service,
+// the day is represented as midnight UTC.
+func calendarDay(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// isoWeekStart returns the Monday of the ISO week of the day.
+func isoWeekStart(day time.Time) time.Time {
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+func loadTimeZone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, status.Error(400, "Invalid time zone")
+	}
+
+	return loc, nil
+}
+
+func timeZoneOrUTC(name string) string {
+	if name == "" {
+		return time.UTC.String()
+	}
+
+	return name
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendarDay(t *testing.T) {
+	auckland, err := time.LoadLocation("Pacific/Auckland")
+	if err != nil {
+		t.Fatalf("load time zone: %v", err)
+	}
+
+	losAngeles, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatalf("load time zone: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		loc  *time.Location
+		want time.Time
+	}{
+		{name: "UTC", t: time.Date(2026, time.March, 10, 23, 59, 0, 0, time.UTC), loc: time.UTC, want: date(2026, time.March, 10)},
+		{name: "ahead of UTC", t: time.Date(2026, time.March, 10, 20, 0, 0, 0, time.UTC), loc: auckland, want: date(2026, time.March, 11)},
+		{name: "behind UTC", t: time.Date(2026, time.March, 10, 3, 0, 0, 0, time.UTC), loc: losAngeles, want: date(2026, time.March, 9)},
+		{name: "earliest time zone", t: time.Date(2026, time.March, 10, 10, 0, 0, 0, time.UTC), loc: earliestTimeZone, want: date(2026, time.March, 11)},
+		{name: "latest time zone", t: time.Date(2026, time.March, 10, 11, 0, 0, 0, time.UTC), loc: latestTimeZone, want: date(2026, time.March, 9)},
+		// Clocks in Los Angeles move forward at 02:00 on the 8th of March 2026.
+		{name: "daylight saving time change", t: time.Date(2026, time.March, 9, 7, 30, 0, 0, time.UTC), loc: losAngeles, want: date(2026, time.March, 9)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calendarDay(tt.t, tt.loc)
+
+			if !got.Equal(tt.want) || got.Location() != time.UTC {
+				t.Errorf("calendarDay = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc/status"
+	pb "ryg-task-service/gen_proto/task_service"
+	"ryg-task-service/model"
+	"time"
+)
+
+// SetChallengeGracePeriod changes the number of past days participants may still update their statuses for.
+func (s *ChallengeService) SetChallengeGracePeriod(ctx context.Context, req *pb.SetChallengeGracePeriodRequest) (*pb.Challenge, error) {
+	challenge, err := s.ValidateChallengeOwnedByUser(req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateSetChallengeGracePeriodRequest(req, challenge); err != nil {
+		return nil, err
+	}
+
+	challenge.GraceDays = req.GraceDays
+
+	if err := s.db.WithContext(context.Background()).Save(&challenge).Error; err != nil {
+		return nil, err
+	}
+
+	return toPbChallenge(challenge), nil
+}
+
+func validateSetChallengeGracePeriodRequest(req *pb.SetChallengeGracePeriodRequest, challenge *model.Challenge) error {
+	if challenge.Status == model.ChallengeStatusFinished {
+		return status.Error(400, "Cannot change grace period of finished challenge")
+	}
+
+	if req.GraceDays == nil {
+		return nil
+	}
+
+	if *req.GraceDays < 0 {
+		return status.Error(400, "Grace period cannot be negative")
+	}
+
+	if *req.GraceDays >= challenge.Days {
+		return status.Error(400, "Grace period should be shorter than the challenge")
+	}
+
+	return nil
+}
+
+// graceStart returns the earliest day participants of the challenge may still update their statuses for.
+func (s *ChallengeService) graceStart(challenge *model.Challenge, today time.Time) time.Time {
+	return today.AddDate(0, 0, -int(s.graceDays(challenge)))
+}
+
+func (s *ChallengeService) graceDays(challenge *model.Challenge) int32 {
+	if challenge.GraceDays != nil {
+		return *challenge.GraceDays
+	}
+
+	return s.defaultGraceDays
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"ryg-task-service/model"
+	"testing"
+	"time"
+)
+
+func TestGraceStart(t *testing.T) {
+	today := date(2026, time.March, 12)
+	none, three := int32(0), int32(3)
+
+	tests := []struct {
+		name      string
+		graceDays *int32
+		want      time.Time
+	}{
+		{name: "default grace period", want: date(2026, time.March, 11)},
+		{name: "no grace period", graceDays: &none, want: today},
+		{name: "grace period of the challenge", graceDays: &three, want: date(2026, time.March, 9)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			challengeService := &ChallengeService{defaultGraceDays: 1}
+
+			got := challengeService.graceStart(&model.Challenge{GraceDays: tt.graceDays}, today)
+
+			if !got.Equal(tt.want) {
+				t.Errorf("graceStart = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	pb "ryg-task-service/gen_proto/task_service"
+	"ryg-task-service/model"
+)
+
+// saveTaskStatus moves the task status of a challenge to the new status, saves it and refreshes the streaks it affects.
+func (s *TaskService) saveTaskStatus(tx *gorm.DB, challengeId int64, taskAndStatus *model.TaskAndStatus, newStatus model.TaskStatus, actorId int64) error {
+	changed, err := s.writeTaskStatus(tx, taskAndStatus, newStatus, actorId)
+	if err != nil || !changed {
+		return err
+	}
+
+	return s.refreshStreaks(tx, challengeId, taskAndStatus.UserID, []int64{taskAndStatus.TaskID}, taskAndStatus.Date)
+}
+
+// writeTaskStatus moves the task status to the new status and saves it, reporting whether the status changed.
+// The move is checked against the status stored for the day, so every writer follows the allowed transitions.
+// Every change of the status is appended to the status history together with the user who made it.
+// Streaks are left to the caller.
+func (s *TaskService) writeTaskStatus(tx *gorm.DB, taskAndStatus *model.TaskAndStatus, newStatus model.TaskStatus, actorId int64) (bool, error) {
+	var oldStatus model.TaskStatus
+
+	err := tx.Model(&model.TaskAndStatus{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("status").
+		Where("task_id = ? AND user_id = ? AND date = ?", taskAndStatus.TaskID, taskAndStatus.UserID, taskAndStatus.Date).
+		Scan(&oldStatus).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := validateTaskStatusTransition(oldStatus, newStatus); err != nil {
+		return false, err
+	}
+
+	taskAndStatus.Status = newStatus
+
+	if err := tx.WithContext(context.Background()).Omit(clause.Associations).Save(taskAndStatus).Error; err != nil {
+		return false, err
+	}
+
+	if oldStatus == newStatus {
+		return false, nil
+	}
+
+	change := &model.TaskStatusChange{
+		TaskID:    taskAndStatus.TaskID,
+		UserID:    taskAndStatus.UserID,
+		Date:      taskAndStatus.Date,
+		OldStatus: oldStatus,
+		NewStatus: newStatus,
+		ActorID:   &actorId,
+		ChangedAt: s.clock.Now(),
+	}
+
+	if err := tx.WithContext(context.Background()).Create(change).Error; err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetTaskStatusHistory returns every change of the status of a task for a participant and a day, oldest first.
+// Any member of the challenge can read the history, so that disputes can be settled.
+func (s *TaskService) GetTaskStatusHistory(ctx context.Context, req *pb.GetTaskStatusHistoryRequest) (*pb.TaskStatusChangeList, error) {
+	task, err := s.validateUserCanReadTask(req.TaskId, req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	participantId := req.UserId
+	if req.ParticipantId != nil {
+		participantId = *req.ParticipantId
+	}
+
+	var changes []model.TaskStatusChange
+
+	err = s.db.WithContext(ctx).
+		Where("task_id = ? AND user_id = ? AND date = ?", task.ID, participantId, req.Date.AsTime()).
+		Order("changed_at, id").
+		Find(&changes).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.TaskStatusChangeList{
+		Changes: make([]*pb.TaskStatusChange, 0, len(changes)),
+	}
+
+	for _, change := range changes {
+		resp.Changes = append(resp.Changes, toPbTaskStatusChange(&change))
+	}
+
+	return resp, nil
+}
+
+func toPbTaskStatusChange(change *model.TaskStatusChange) *pb.TaskStatusChange {
+	return &pb.TaskStatusChange{
+		TaskId:    change.TaskID,
+		UserId:    change.UserID,
+		Date:      timestamppb.New(change.Date),
+		OldStatus: toPbTaskStatus(change.OldStatus),
+		NewStatus: toPbTaskStatus(change.NewStatus),
+		ActorId:   change.ActorID,
+		ChangedAt: timestamppb.New(change.ChangedAt),
+	}
+}
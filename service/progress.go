@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	pb "ryg-task-service/gen_proto/task_service"
+	"ryg-task-service/model"
+	"time"
+)
+
+// unsettledWeeklyTaskStatus excludes the not started statuses of TIMES_PER_WEEK tasks. Whether such a day counts
+// as missed is only known once its week is settled.
+const unsettledWeeklyTaskStatus = "NOT (tasks.recurrence_type = ? AND task_and_status.status = ?)"
+
+type tagCompletion struct {
+	Tag       string
+	Completed int32
+	Total     int32
+}
+
+type dayScore struct {
+	Date                   time.Time
+	RequiredTasks          int32
+	CompletedRequiredTasks int32
+	FailedRequiredTasks    int32
+	RequiredPoints         int32
+	MaxRequiredPoints      int32
+	BonusPoints            int32
+}
+
+// Successful reports whether the day has required tasks and every one of them is completed.
+func (d *dayScore) Successful() bool {
+	return d.RequiredTasks > 0 && d.CompletedRequiredTasks == d.RequiredTasks
+}
+
+// Counts reports whether the day counts towards the success rate. Days without required tasks never do, and
+// today only does once it is successful or one of its required tasks is not completed.
+func (d *dayScore) Counts(today time.Time) bool {
+	if d.RequiredTasks == 0 {
+		return false
+	}
+
+	return d.Date.Before(today) || d.Successful() || d.FailedRequiredTasks > 0
+}
+
+// Score is the weight of every completed task, optional tasks adding bonus points on top of the required ones.
+func (d *dayScore) Score() int32 {
+	return d.RequiredPoints + d.BonusPoints
+}
+
+// GetTagCompletion breaks the completion of the user's tasks down by tag. Only the days up to today count,
+// and neutral statuses and unsettled days of TIMES_PER_WEEK tasks are left out.
+func (s *TaskService) GetTagCompletion(ctx context.Context, req *pb.GetTagCompletionRequest) (*pb.TagCompletionList, error) {
+	if _, err := s.ChallengeSvs.ValidateUserCanReadChallenge(req.ChallengeId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	today, err := s.ChallengeSvs.userToday(req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	var tagCompletions []tagCompletion
+
+	err = s.db.WithContext(ctx).
+		Model(&model.TaskAndStatus{}).
+		Select("tag.value AS tag, COUNT(*) FILTER (WHERE task_and_status.status = ?) AS completed, COUNT(*) AS total", model.TaskStatusCompleted).
+		Joins("JOIN tasks ON tasks.id = task_and_status.task_id").
+		Joins("CROSS JOIN LATERAL jsonb_array_elements_text(COALESCE(tasks.tags, '[]'::jsonb)) AS tag(value)").
+		Where("tasks.challenge_id = ? AND task_and_status.user_id = ? AND task_and_status.date <= ?", req.ChallengeId, req.UserId, today).
+		Where("task_and_status.status NOT IN ?", model.NeutralTaskStatuses).
+		Where(unsettledWeeklyTaskStatus, model.RecurrenceTimesPerWeek, model.TaskStatusNotStarted).
+		Group("tag.value").
+		Order("tag.value").
+		Scan(&tagCompletions).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.TagCompletionList{
+		TagCompletions: make([]*pb.TagCompletion, 0),
+	}
+
+	for _, completion := range tagCompletions {
+		resp.TagCompletions = append(resp.TagCompletions, &pb.TagCompletion{
+			Tag:            completion.Tag,
+			Completed:      completion.Completed,
+			Total:          completion.Total,
+			CompletionRate: completionRate(completion.Completed, completion.Total),
+		})
+	}
+
+	return resp, nil
+}
+
+func completionRate(completed, total int32) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return float64(completed) / float64(total)
+}
+
+// GetChallengeProgress scores every day of the challenge for the user up to today. Tasks with a neutral status
+// are left out, so a day where every task is excused does not count at all. So are TIMES_PER_WEEK tasks on days
+// their week has not been settled for yet. Only the days that count make up the success rate.
+func (s *TaskService) GetChallengeProgress(ctx context.Context, req *pb.GetChallengeProgressRequest) (*pb.ChallengeProgress, error) {
+	if _, err := s.ChallengeSvs.ValidateUserCanReadChallenge(req.ChallengeId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	today, err := s.ChallengeSvs.userToday(req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	var dayScores []dayScore
+
+	err = s.db.WithContext(ctx).
+		Model(&model.TaskAndStatus{}).
+		Select(`task_and_status.date AS date,
+			COUNT(*) FILTER (WHERE tasks.required) AS required_tasks,
+			COUNT(*) FILTER (WHERE tasks.required AND task_and_status.status = @completed) AS completed_required_tasks,
+			COUNT(*) FILTER (WHERE tasks.required AND task_and_status.status = @notCompleted) AS failed_required_tasks,
+			COALESCE(SUM(tasks.weight) FILTER (WHERE tasks.required AND task_and_status.status = @completed), 0) AS required_points,
+			COALESCE(SUM(tasks.weight) FILTER (WHERE tasks.required), 0) AS max_required_points,
+			COALESCE(SUM(tasks.weight) FILTER (WHERE NOT tasks.required AND task_and_status.status = @completed), 0) AS bonus_points`,
+			map[string]interface{}{"completed": model.TaskStatusCompleted, "notCompleted": model.TaskStatusNotCompleted}).
+		Joins("JOIN tasks ON tasks.id = task_and_status.task_id").
+		Where("tasks.challenge_id = ? AND task_and_status.user_id = ? AND task_and_status.date <= ?", req.ChallengeId, req.UserId, today).
+		Where("task_and_status.status NOT IN ?", model.NeutralTaskStatuses).
+		Where(unsettledWeeklyTaskStatus, model.RecurrenceTimesPerWeek, model.TaskStatusNotStarted).
+		Group("task_and_status.date").
+		Order("task_and_status.date").
+		Scan(&dayScores).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ChallengeProgress{
+		Days: make([]*pb.DayScore, 0, len(dayScores)),
+	}
+
+	for _, day := range dayScores {
+		resp.Score += day.Score()
+
+		if day.Counts(today) {
+			resp.TotalDays++
+
+			if day.Successful() {
+				resp.SuccessfulDays++
+			}
+		}
+
+		resp.Days = append(resp.Days, &pb.DayScore{
+			Date:              timestamppb.New(day.Date),
+			Successful:        day.Successful(),
+			RequiredPoints:    day.RequiredPoints,
+			MaxRequiredPoints: day.MaxRequiredPoints,
+			BonusPoints:       day.BonusPoints,
+			Score:             day.Score(),
+		})
+	}
+
+	resp.SuccessRate = completionRate(resp.SuccessfulDays, resp.TotalDays)
+
+	return resp, nil
+}
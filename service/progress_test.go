@@ -0,0 +1,61 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayScoreCounts(t *testing.T) {
+	today := date(2026, time.March, 12)
+	yesterday := date(2026, time.March, 11)
+
+	tests := []struct {
+		name           string
+		day            dayScore
+		wantCounts     bool
+		wantSuccessful bool
+	}{
+		{
+			name: "only optional tasks",
+			day:  dayScore{Date: yesterday, BonusPoints: 2},
+		},
+		{
+			name:           "every required task completed",
+			day:            dayScore{Date: yesterday, RequiredTasks: 2, CompletedRequiredTasks: 2},
+			wantCounts:     true,
+			wantSuccessful: true,
+		},
+		{
+			name:       "required task left pending on a past day",
+			day:        dayScore{Date: yesterday, RequiredTasks: 2, CompletedRequiredTasks: 1},
+			wantCounts: true,
+		},
+		{
+			name: "required task still pending today",
+			day:  dayScore{Date: today, RequiredTasks: 2, CompletedRequiredTasks: 1},
+		},
+		{
+			name:       "required task not completed today",
+			day:        dayScore{Date: today, RequiredTasks: 2, CompletedRequiredTasks: 1, FailedRequiredTasks: 1},
+			wantCounts: true,
+		},
+		{
+			name:           "every required task completed today",
+			day:            dayScore{Date: today, RequiredTasks: 2, CompletedRequiredTasks: 2},
+			wantCounts:     true,
+			wantSuccessful: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.day.Counts(today); got != tt.wantCounts {
+				t.Errorf("Counts = %t, want %t", got, tt.wantCounts)
+			}
+
+			if got := tt.day.Successful(); got != tt.wantSuccessful {
+				t.Errorf("Successful = %t, want %t", got, tt.wantSuccessful)
+			}
+		})
+	}
+}
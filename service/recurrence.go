@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+	pb "ryg-task-service/gen_proto/task_service"
+	"ryg-task-service/model"
+	"sort"
+	"time"
+)
+
+type weeklyTaskStatusKey struct {
+	taskId int64
+	userId int64
+	week   time.Time
+}
+
+// recurrenceFromPb converts the recurrence of a request. Requests without one keep the
+// WEEK_DAYS behaviour of clients that only send week days.
+func recurrenceFromPb(recurrence *pb.Recurrence) model.Recurrence {
+	if recurrence == nil || recurrence.Type == "" {
+		return model.Recurrence{Type: model.RecurrenceWeekDays}
+	}
+
+	resp := model.Recurrence{
+		Type:     recurrence.Type,
+		Interval: recurrence.Interval,
+	}
+
+	seen := make(map[time.Time]bool, len(recurrence.Dates))
+
+	for _, date := range recurrence.Dates {
+		day := calendarDay(date.AsTime(), time.UTC)
+
+		if seen[day] {
+			continue
+		}
+
+		seen[day] = true
+		resp.Dates = append(resp.Dates, day)
+	}
+
+	sort.Slice(resp.Dates, func(i, j int) bool {
+		return resp.Dates[i].Before(resp.Dates[j])
+	})
+
+	return resp
+}
+
+// taskWeekDays returns the week days stored for a task, so that clients reading only week days
+// still see every day for EVERY_DAY tasks.
+func taskWeekDays(recurrence model.Recurrence, weekDays int32) model.WeekDays {
+	if recurrence.Type == model.RecurrenceEveryDay {
+		return model.AllWeekDays
+	}
+
+	return model.WeekDays(weekDays)
+}
+
+func validateTaskRecurrence(recurrence model.Recurrence, weekDays int32) error {
+	if weekDays < 0 || weekDays > int32(model.AllWeekDays) {
+		return status.Error(400, "Invalid week days")
+	}
+
+	switch recurrence.Type {
+	case model.RecurrenceWeekDays:
+		if weekDays == 0 {
+			return status.Error(400, "Invalid week days")
+		}
+	case model.RecurrenceEveryDay:
+	case model.RecurrenceEveryNDays:
+		if recurrence.Interval < 1 {
+			return status.Error(400, "Interval must be at least 1 day")
+		}
+	case model.RecurrenceTimesPerWeek:
+		if recurrence.Interval < 1 || recurrence.Interval > 7 {
+			return status.Error(400, "Times per week must be between 1 and 7")
+		}
+	case model.RecurrenceSpecificDates:
+		if len(recurrence.Dates) == 0 {
+			return status.Error(400, "At least one date is required")
+		}
+	default:
+		return status.Error(400, "Invalid recurrence type")
+	}
+
+	return nil
+}
+
+// shiftRecurrenceDates moves the dates of a SPECIFIC_DATES recurrence by the given number of days.
+func shiftRecurrenceDates(recurrence model.Recurrence, days int) model.Recurrence {
+	if days == 0 || len(recurrence.Dates) == 0 {
+		return recurrence
+	}
+
+	dates := make([]time.Time, 0, len(recurrence.Dates))
+	for _, date := range recurrence.Dates {
+		dates = append(dates, date.AddDate(0, 0, days))
+	}
+
+	recurrence.Dates = dates
+
+	return recurrence
+}
+
+// validateTasksFallOnChallenge checks that every SPECIFIC_DATES task of the challenge has a date in [start, end),
+// which tasks copied from another challenge or a template may not have.
+func validateTasksFallOnChallenge(tx *gorm.DB, challengeId int64, start, end time.Time) error {
+	var tasks []model.Task
+
+	if err := tx.Where("challenge_id = ? AND archived_at IS NULL AND recurrence_type = ?", challengeId, model.RecurrenceSpecificDates).Find(&tasks).Error; err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		if !hasDateWithin(task.Recurrence, start, end) {
+			return status.Error(400, fmt.Sprintf("Task %q does not fall on any day of the challenge", task.Title))
+		}
+	}
+
+	return nil
+}
+
+func hasDateWithin(recurrence model.Recurrence, start, end time.Time) bool {
+	for _, date := range recurrence.Dates {
+		if !date.Before(start) && date.Before(end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toPbRecurrence(recurrence model.Recurrence) *pb.Recurrence {
+	resp := &pb.Recurrence{
+		Type:     recurrence.Type,
+		Interval: recurrence.Interval,
+	}
+
+	for _, date := range recurrence.Dates {
+		resp.Dates = append(resp.Dates, timestamppb.New(date))
+	}
+
+	return resp
+}
+
+// SettleElapsedWeeks settles the TIMES_PER_WEEK tasks of started challenges for every week whose days are all
+// outside the grace period in every time zone.
+func (s *ChallengeService) SettleElapsedWeeks() error {
+	today := calendarDay(s.clock.Now(), latestTimeZone)
+
+	weeklyChallengeIds := s.db.Model(&model.Task{}).Select("challenge_id").Where("recurrence_type = ?", model.RecurrenceTimesPerWeek)
+
+	var challenges []model.Challenge
+
+	if err := s.db.WithContext(context.Background()).Where("status = ? AND id IN (?)", model.ChallengeStatusStarted, weeklyChallengeIds).Find(&challenges).Error; err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for i := range challenges {
+		before := isoWeekStart(s.graceStart(&challenges[i], today))
+
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			settled, err := s.TaskSvs.settleWeeklyTaskStatuses(tx, challenges[i].ID, before)
+			if err != nil || settled == 0 {
+				return err
+			}
+
+			return s.TaskSvs.refreshChallengeStreaks(tx, challenges[i].ID)
+		})
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("settle weeks of challenge %d: %w", challenges[i].ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// settleWeeklyTaskStatuses settles the weeks of the TIMES_PER_WEEK tasks of the challenge, counting only the days
+// before the given one. Of the days of a week left not started, as many as the quota of the week still misses are
+// marked as not completed, the latest first, and the others as skipped. Excused days do not count towards the quota.
+// The changes are recorded in the status history without an actor, and the number of settled statuses is returned.
+// Streaks are left to the caller.
+func (s *TaskService) settleWeeklyTaskStatuses(tx *gorm.DB, challengeId int64, before time.Time) (int, error) {
+	weeklyTaskIds := challengeTaskIds(tx, challengeId).Where("recurrence_type = ?", model.RecurrenceTimesPerWeek)
+
+	var unsettled int64
+
+	if err := tx.Model(&model.TaskAndStatus{}).Where("task_id IN (?) AND status = ? AND date < ?", weeklyTaskIds, model.TaskStatusNotStarted, before).Count(&unsettled).Error; err != nil {
+		return 0, err
+	}
+
+	if unsettled == 0 {
+		return 0, nil
+	}
+
+	var taskAndStatuses []model.TaskAndStatus
+
+	if err := tx.Preload("Task").Where("task_id IN (?) AND date < ?", weeklyTaskIds, before).Order("date").Find(&taskAndStatuses).Error; err != nil {
+		return 0, err
+	}
+
+	var keys []weeklyTaskStatusKey
+	weeks := make(map[weeklyTaskStatusKey][]*model.TaskAndStatus)
+
+	for i := range taskAndStatuses {
+		key := weeklyTaskStatusKey{taskAndStatuses[i].TaskID, taskAndStatuses[i].UserID, isoWeekStart(taskAndStatuses[i].Date)}
+
+		if _, ok := weeks[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		weeks[key] = append(weeks[key], &taskAndStatuses[i])
+	}
+
+	now := s.clock.Now()
+	var changes []model.TaskStatusChange
+
+	for _, key := range keys {
+		days, counted := 0, 0
+		var notStarted []*model.TaskAndStatus
+
+		for _, taskAndStatus := range weeks[key] {
+			switch taskAndStatus.Status {
+			case model.TaskStatusCompleted, model.TaskStatusNotCompleted:
+				counted++
+			case model.TaskStatusNotStarted:
+				notStarted = append(notStarted, taskAndStatus)
+			}
+
+			if taskAndStatus.Status != model.TaskStatusExcused {
+				days++
+			}
+		}
+
+		missing := weeks[key][0].Task.WeeklyQuota(days) - counted
+
+		for i, taskAndStatus := range notStarted {
+			newStatus := model.TaskStatusSkipped
+			if i >= len(notStarted)-missing {
+				newStatus = model.TaskStatusNotCompleted
+			}
+
+			err := tx.WithContext(context.Background()).
+				Model(&model.TaskAndStatus{}).
+				Where("task_id = ? AND user_id = ? AND date = ?", taskAndStatus.TaskID, taskAndStatus.UserID, taskAndStatus.Date).
+				Update("status", newStatus).Error
+
+			if err != nil {
+				return 0, err
+			}
+
+			changes = append(changes, model.TaskStatusChange{
+				TaskID:    taskAndStatus.TaskID,
+				UserID:    taskAndStatus.UserID,
+				Date:      taskAndStatus.Date,
+				OldStatus: taskAndStatus.Status,
+				NewStatus: newStatus,
+				ChangedAt: now,
+			})
+		}
+	}
+
+	if len(changes) == 0 {
+		return 0, nil
+	}
+
+	return len(changes), tx.WithContext(context.Background()).Create(&changes).Error
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"ryg-task-service/model"
+	"testing"
+	"time"
+)
+
+func TestShiftRecurrenceDates(t *testing.T) {
+	recurrence := model.Recurrence{
+		Type:  model.RecurrenceSpecificDates,
+		Dates: []time.Time{date(2026, time.March, 10), date(2026, time.March, 28)},
+	}
+
+	shifted := shiftRecurrenceDates(recurrence, 28)
+
+	want := []time.Time{date(2026, time.April, 7), date(2026, time.April, 25)}
+	if !sameDates(shifted.Dates, want) {
+		t.Errorf("shifted dates = %v, want %v", shifted.Dates, want)
+	}
+
+	if !recurrence.Dates[0].Equal(date(2026, time.March, 10)) {
+		t.Errorf("original dates changed to %v", recurrence.Dates)
+	}
+}
+
+func TestHasDateWithin(t *testing.T) {
+	// The challenge runs from the 10th to the 14th of March.
+	start, end := date(2026, time.March, 10), date(2026, time.March, 15)
+
+	tests := []struct {
+		name  string
+		dates []time.Time
+		want  bool
+	}{
+		{name: "first day", dates: []time.Time{date(2026, time.March, 10)}, want: true},
+		{name: "last day", dates: []time.Time{date(2026, time.March, 14)}, want: true},
+		{name: "before the start", dates: []time.Time{date(2026, time.March, 9)}},
+		{name: "on the end date", dates: []time.Time{date(2026, time.March, 15)}},
+		{name: "one date of several", dates: []time.Time{date(2026, time.February, 10), date(2026, time.March, 12)}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurrence := model.Recurrence{Type: model.RecurrenceSpecificDates, Dates: tt.dates}
+
+			if got := hasDateWithin(recurrence, start, end); got != tt.want {
+				t.Errorf("hasDateWithin = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	pb "ryg-task-service/gen_proto/task_service"
+	"ryg-task-service/model"
+	"time"
+)
+
+func (s *ChallengeService) SetChallengeRecurrence(ctx context.Context, req *pb.SetChallengeRecurrenceRequest) (*pb.Challenge, error) {
+	challenge, err := s.ValidateChallengeOwnedByUser(req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateSetChallengeRecurrenceRequest(req, challenge); err != nil {
+		return nil, err
+	}
+
+	challenge.RecurrenceIntervalDays = req.RecurrenceIntervalDays
+
+	if challenge.SeriesID == nil && challenge.RecurrenceIntervalDays > 0 {
+		challenge.SeriesID = &challenge.ID
+	}
+
+	if err := s.db.WithContext(context.Background()).Save(&challenge).Error; err != nil {
+		return nil, err
+	}
+
+	return toPbChallenge(challenge), nil
+}
+
+func validateSetChallengeRecurrenceRequest(req *pb.SetChallengeRecurrenceRequest, challenge *model.Challenge) error {
+	if challenge.Status == model.ChallengeStatusFinished {
+		return status.Error(400, "Cannot change recurrence of finished challenge")
+	}
+
+	if req.RecurrenceIntervalDays < 0 {
+		return status.Error(400, "Recurrence interval cannot be negative")
+	}
+
+	if req.RecurrenceIntervalDays > 0 && req.RecurrenceIntervalDays < challenge.Days {
+		return status.Error(400, "Recurrence interval cannot be shorter than the challenge")
+	}
+
+	return nil
+}
+
+// GetChallengeSeries returns the iterations of the series of the challenge that the user belongs to.
+func (s *ChallengeService) GetChallengeSeries(ctx context.Context, req *pb.GetChallengeSeriesRequest) (*pb.ChallengeList, error) {
+	challenge, err := s.ValidateUserCanReadChallenge(req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	challenges := []model.Challenge{*challenge}
+
+	if challenge.SeriesID != nil {
+		err := s.db.WithContext(ctx).
+			Joins("JOIN challenge_and_users ON challenge_and_users.challenge_id = challenges.id").
+			Where("challenges.series_id = ? AND challenge_and_users.user_id = ?", *challenge.SeriesID, req.UserId).
+			Order("challenges.iteration").
+			Find(&challenges).Error
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &pb.ChallengeList{
+		Challenges: make([]*pb.Challenge, 0),
+	}
+
+	for i := range challenges {
+		resp.Challenges = append(resp.Challenges, toPbChallenge(&challenges[i]))
+	}
+
+	return resp, nil
+}
+
+// startNextIteration creates the next iteration of a recurring challenge with the same tasks and
+// participants. It is started right away when its start date has come and scheduled otherwise. An overdue
+// iteration still starts on its own start date, so the series does not drift when it is rolled over late.
+func (s *ChallengeService) startNextIteration(tx *gorm.DB, challenge *model.Challenge, today time.Time) error {
+	next := &model.Challenge{
+		Title:                  challenge.Title,
+		Description:            challenge.Description,
+		Status:                 model.ChallengeStatusDraft,
+		Days:                   challenge.Days,
+		RecurrenceIntervalDays: challenge.RecurrenceIntervalDays,
+		SeriesID:               challenge.SeriesID,
+		Iteration:              challenge.Iteration + 1,
+		GraceDays:              challenge.GraceDays,
+	}
+
+	// The unique series iteration index prevents the same iteration from being created twice.
+	if err := tx.WithContext(context.Background()).Create(&next).Error; err != nil {
+		return err
+	}
+
+	var participants []model.ChallengeAndUser
+
+	if err := tx.Find(&participants, "challenge_id = ?", challenge.ID).Error; err != nil {
+		return err
+	}
+
+	for i := range participants {
+		participants[i].ChallengeID = next.ID
+	}
+
+	if err := tx.WithContext(context.Background()).Create(&participants).Error; err != nil {
+		return err
+	}
+
+	// The participants are copied first, so that the tasks keep their assignees.
+	if err := copyTasks(tx, challenge.ID, next.ID, int(challenge.RecurrenceIntervalDays)); err != nil {
+		return err
+	}
+
+	startDate := challenge.StartDate.AddDate(0, 0, int(challenge.RecurrenceIntervalDays))
+
+	if startDate.After(today) {
+		next.StartDate = startDate
+		next.EndDate = startDate.AddDate(0, 0, int(next.Days))
+		next.Status = model.ChallengeStatusScheduled
+
+		return tx.WithContext(context.Background()).Save(&next).Error
+	}
+
+	return s.startChallenge(tx, next, startDate)
+}
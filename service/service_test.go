@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc/status"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"os"
+	"ryg-task-service/clock"
+	"ryg-task-service/conf"
+	"ryg-task-service/db"
+	"ryg-task-service/gen_proto/email_service"
+	pb "ryg-task-service/gen_proto/task_service"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testOwnerId       int64 = 1
+	testParticipantId int64 = 2
+)
+
+type fakeEmailPublisher struct {
+	emails []*email_service.GenericEmail
+}
+
+func (p *fakeEmailPublisher) Publish(email *email_service.GenericEmail) error {
+	p.emails = append(p.emails, email)
+	return nil
+}
+
+// newTestServices wires the services to the database of TEST_DATABASE_DSN, emptied beforehand.
+// Tests that need a database are skipped when it is not set.
+func newTestServices(t testing.TB, clk clock.Clock) (*ChallengeService, *TaskService) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	gdb, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("connect to the database: %v", err)
+	}
+
+	if err := db.Migrate(gdb); err != nil {
+		t.Fatalf("migrate the database: %v", err)
+	}
+
+	var tables []string
+
+	if err := gdb.Table("pg_tables").Where("schemaname = current_schema()").Pluck("tablename", &tables).Error; err != nil {
+		t.Fatalf("list the tables: %v", err)
+	}
+
+	if err := gdb.Exec("TRUNCATE " + strings.Join(tables, ", ") + " RESTART IDENTITY CASCADE").Error; err != nil {
+		t.Fatalf("empty the database: %v", err)
+	}
+
+	taskService := NewTaskService(gdb, clk)
+	challengeService := NewChallengeService(gdb, &fakeEmailPublisher{}, clk, conf.ChallengeConfig{MaxDays: 365, GraceDays: 1})
+
+	taskService.ChallengeSvs = challengeService
+	challengeService.TaskSvs = taskService
+
+	return challengeService, taskService
+}
+
+// createTestChallenge creates a draft challenge of the given number of days owned by testOwnerId.
+func createTestChallenge(t testing.TB, challengeService *ChallengeService, days int32, timeZone string) *pb.Challenge {
+	challenge, err := challengeService.CreateChallenge(context.Background(), &pb.CreateChallengeRequest{
+		Title:    "Challenge",
+		UserId:   testOwnerId,
+		Days:     days,
+		TimeZone: timeZone,
+	})
+
+	if err != nil {
+		t.Fatalf("create challenge: %v", err)
+	}
+
+	return challenge
+}
+
+func startTestChallenge(t testing.TB, challengeService *ChallengeService, challengeId int64) {
+	_, err := challengeService.StartChallenge(context.Background(), &pb.StartChallengeRequest{ChallengeId: challengeId, UserId: testOwnerId})
+	if err != nil {
+		t.Fatalf("start challenge: %v", err)
+	}
+}
+
+// addTestParticipant invites testParticipantId to the challenge and subscribes them from the given time zone.
+func addTestParticipant(t testing.TB, challengeService *ChallengeService, challengeId int64, timeZone string) {
+	_, err := challengeService.AddUserToChallenge(context.Background(), &pb.AddUserToChallengeRequest{
+		UserId:      testOwnerId,
+		ChallengeId: challengeId,
+		UserToAddId: testParticipantId,
+		Email:       "participant@example.com",
+	})
+
+	if err != nil {
+		t.Fatalf("add user to challenge: %v", err)
+	}
+
+	token, err := GenerateJWT(challengeService.clock, testParticipantId, challengeId)
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+
+	if _, err := challengeService.SubscribeToChallenge(context.Background(), &pb.SubscribeToChallengeRequest{Token: token, TimeZone: timeZone}); err != nil {
+		t.Fatalf("subscribe to challenge: %v", err)
+	}
+}
+
+func pauseTestChallenge(t testing.TB, challengeService *ChallengeService, challengeId int64) {
+	_, err := challengeService.PauseChallenge(context.Background(), &pb.PauseChallengeRequest{ChallengeId: challengeId, UserId: testOwnerId})
+	if err != nil {
+		t.Fatalf("pause challenge: %v", err)
+	}
+}
+
+// errorMessage returns the message of the status error, or an empty string when there is none.
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return status.Convert(err).Message()
+}
+
+// date returns midnight UTC of the given day, the way the service stores dates.
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	pb "ryg-task-service/gen_proto/task_service"
+	"ryg-task-service/model"
+	"time"
+)
+
+type streakOutcome int
+
+const (
+	streakDayPending streakOutcome = iota
+	streakDaySuccessful
+	streakDayFailed
+	streakDayNeutral
+)
+
+type streakDay struct {
+	date    time.Time
+	outcome streakOutcome
+}
+
+type challengeStreakDay struct {
+	Date                   time.Time
+	RequiredTasks          int32
+	CompletedRequiredTasks int32
+	FailedRequiredTasks    int32
+}
+
+// GetStreaks returns the challenge streak of the user and the streaks of the tasks assigned to them.
+func (s *TaskService) GetStreaks(ctx context.Context, req *pb.GetStreaksRequest) (*pb.StreakList, error) {
+	if _, err := s.ChallengeSvs.ValidateUserCanReadChallenge(req.ChallengeId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	today, err := s.ChallengeSvs.userToday(req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := getTasksByChallengeId(s.db, req.ChallengeId)
+	if err != nil {
+		return nil, err
+	}
+
+	var streaks []model.Streak
+
+	if err := s.db.WithContext(ctx).Where("challenge_id = ? AND user_id = ?", req.ChallengeId, req.UserId).Find(&streaks).Error; err != nil {
+		return nil, err
+	}
+
+	streaksByTaskId := make(map[int64]*model.Streak, len(streaks))
+	for i := range streaks {
+		streaksByTaskId[streaks[i].TaskID] = &streaks[i]
+	}
+
+	resp := &pb.StreakList{
+		Challenge: toPbStreak(0, streaksByTaskId[0], today),
+		Tasks:     make([]*pb.Streak, 0, len(tasks)),
+	}
+
+	for _, task := range tasks {
+		if !task.IsAssignedTo(req.UserId) {
+			continue
+		}
+
+		resp.Tasks = append(resp.Tasks, toPbStreak(task.ID, streaksByTaskId[task.ID], today))
+	}
+
+	return resp, nil
+}
+
+// getStreak returns the stored streak, or nil when the user has none yet.
+func getStreak(tx *gorm.DB, challengeId, userId, taskId int64) (*model.Streak, error) {
+	var streak model.Streak
+
+	err := tx.First(&streak, "challenge_id = ? AND user_id = ? AND task_id = ?", challengeId, userId, taskId).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &streak, nil
+}
+
+// refreshStreaks brings the streaks of the user for the given tasks of a challenge, along with their challenge
+// streak, up to date with statuses changed on or after changedFrom. A streak still open on that day is continued from
+// the day it stopped on, any other is rebuilt from the first day. Writers changing many statuses refresh them all
+// together afterwards, with a zero changedFrom when the changed days are not known.
+func (s *TaskService) refreshStreaks(tx *gorm.DB, challengeId, userId int64, taskIds []int64, changedFrom time.Time) error {
+	today, err := s.ChallengeSvs.userToday(challengeId, userId)
+	if err != nil {
+		return err
+	}
+
+	var stored []model.Streak
+
+	if err := tx.Where("challenge_id = ? AND user_id = ? AND task_id IN ?", challengeId, userId, append([]int64{0}, taskIds...)).Find(&stored).Error; err != nil {
+		return err
+	}
+
+	storedByTaskId := make(map[int64]model.Streak, len(stored))
+	for _, streak := range stored {
+		storedByTaskId[streak.TaskID] = streak
+	}
+
+	// continuedStreak returns the streak to walk the days from, and the first of those days.
+	continuedStreak := func(taskId int64) (model.Streak, time.Time) {
+		streak, ok := storedByTaskId[taskId]
+		if ok && streak.ExpiresAfter != nil && !changedFrom.Before(*streak.ExpiresAfter) {
+			return streak, *streak.ExpiresAfter
+		}
+
+		return model.Streak{ChallengeID: challengeId, UserID: userId, TaskID: taskId}, time.Time{}
+	}
+
+	challengeStreak, from := continuedStreak(0)
+
+	challengeDays, err := challengeStreakDays(tx, challengeId, userId, from)
+	if err != nil {
+		return err
+	}
+
+	streaks := []model.Streak{continueStreak(challengeStreak, challengeDays, today)}
+
+	taskStreaks, err := s.continuedTaskStreaks(tx, userId, taskIds, continuedStreak, today)
+	if err != nil {
+		return err
+	}
+
+	streaks = append(streaks, taskStreaks...)
+
+	return tx.WithContext(context.Background()).Omit(clause.Associations).Clauses(clause.OnConflict{UpdateAll: true}).Create(&streaks).Error
+}
+
+// continuedTaskStreaks walks the days of every task from the day continuedStreak gives for it. Every status is read
+// in one go from the earliest of those days. Tasks the user has no status and no streak for are left out.
+func (s *TaskService) continuedTaskStreaks(tx *gorm.DB, userId int64, taskIds []int64, continuedStreak func(int64) (model.Streak, time.Time), today time.Time) ([]model.Streak, error) {
+	if len(taskIds) == 0 {
+		return nil, nil
+	}
+
+	var tasks []model.Task
+	if err := tx.Where("id IN ?", taskIds).Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	streaks := make([]model.Streak, len(tasks))
+	froms := make([]time.Time, len(tasks))
+	var earliest time.Time
+
+	for i := range tasks {
+		streaks[i], froms[i] = continuedStreak(tasks[i].ID)
+
+		if i == 0 || froms[i].Before(earliest) {
+			earliest = froms[i]
+		}
+	}
+
+	var taskAndStatuses []model.TaskAndStatus
+
+	if err := tx.Where("task_id IN ? AND user_id = ? AND date >= ?", taskIds, userId, earliest).Order("date").Find(&taskAndStatuses).Error; err != nil {
+		return nil, err
+	}
+
+	taskAndStatusesByTaskId := make(map[int64][]model.TaskAndStatus, len(tasks))
+	for _, taskAndStatus := range taskAndStatuses {
+		taskAndStatusesByTaskId[taskAndStatus.TaskID] = append(taskAndStatusesByTaskId[taskAndStatus.TaskID], taskAndStatus)
+	}
+
+	resp := make([]model.Streak, 0, len(tasks))
+
+	for i := range tasks {
+		days := make([]model.TaskAndStatus, 0, len(taskAndStatusesByTaskId[tasks[i].ID]))
+		for _, taskAndStatus := range taskAndStatusesByTaskId[tasks[i].ID] {
+			if !taskAndStatus.Date.Before(froms[i]) {
+				days = append(days, taskAndStatus)
+			}
+		}
+
+		// A streak that is continued is kept even without days left, so that it no longer expires on a removed day.
+		if len(days) == 0 && froms[i].IsZero() {
+			continue
+		}
+
+		resp = append(resp, continueStreak(streaks[i], taskStreakDays(&tasks[i], days), today))
+	}
+
+	return resp, nil
+}
+
+// refreshStreaksOfParticipants rebuilds, for every participant of the challenge, their challenge streak and their
+// streaks for the given tasks.
+func (s *TaskService) refreshStreaksOfParticipants(tx *gorm.DB, challengeId int64, taskIds []int64) error {
+	participantIds, err := getParticipantIds(tx, challengeId)
+	if err != nil {
+		return err
+	}
+
+	for _, participantId := range participantIds {
+		if err := s.refreshStreaks(tx, challengeId, participantId, taskIds, time.Time{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// refreshChallengeStreaks rebuilds every streak of the challenge, for service-side writers that change the statuses
+// of many tasks and participants at once.
+func (s *TaskService) refreshChallengeStreaks(tx *gorm.DB, challengeId int64) error {
+	taskIds := make([]int64, 0)
+
+	if err := tx.Model(&model.Task{}).Where("challenge_id = ?", challengeId).Pluck("id", &taskIds).Error; err != nil {
+		return err
+	}
+
+	return s.refreshStreaksOfParticipants(tx, challengeId, taskIds)
+}
+
+// taskStreakDays tells for every day of the task whether the user completed it. Days of TIMES_PER_WEEK tasks left
+// not started are neutral until their week is settled.
+func taskStreakDays(task *model.Task, taskAndStatuses []model.TaskAndStatus) []streakDay {
+	days := make([]streakDay, 0, len(taskAndStatuses))
+
+	for _, taskAndStatus := range taskAndStatuses {
+		outcome := streakDayPending
+
+		switch {
+		case taskAndStatus.Status == model.TaskStatusCompleted:
+			outcome = streakDaySuccessful
+		case taskAndStatus.Status == model.TaskStatusNotCompleted:
+			outcome = streakDayFailed
+		case taskAndStatus.Status.IsNeutral(), task.Recurrence.Type == model.RecurrenceTimesPerWeek:
+			outcome = streakDayNeutral
+		}
+
+		days = append(days, streakDay{date: taskAndStatus.Date, outcome: outcome})
+	}
+
+	return days
+}
+
+// challengeStreakDays tells for every day of the challenge from the given one whether the user completed all of its
+// required tasks. Days without required tasks left once neutral statuses and unsettled TIMES_PER_WEEK days are put
+// aside are neutral.
+func challengeStreakDays(tx *gorm.DB, challengeId, userId int64, from time.Time) ([]streakDay, error) {
+	var challengeDays []challengeStreakDay
+
+	err := tx.Model(&model.TaskAndStatus{}).
+		Select(`task_and_status.date AS date,
+			COUNT(*) FILTER (WHERE tasks.required) AS required_tasks,
+			COUNT(*) FILTER (WHERE tasks.required AND task_and_status.status = @completed) AS completed_required_tasks,
+			COUNT(*) FILTER (WHERE tasks.required AND task_and_status.status = @notCompleted) AS failed_required_tasks`,
+			map[string]interface{}{"completed": model.TaskStatusCompleted, "notCompleted": model.TaskStatusNotCompleted}).
+		Joins("JOIN tasks ON tasks.id = task_and_status.task_id").
+		Where("tasks.challenge_id = ? AND task_and_status.user_id = ? AND task_and_status.date >= ?", challengeId, userId, from).
+		Where("task_and_status.status NOT IN ?", model.NeutralTaskStatuses).
+		Where(unsettledWeeklyTaskStatus, model.RecurrenceTimesPerWeek, model.TaskStatusNotStarted).
+		Group("task_and_status.date").
+		Order("task_and_status.date").
+		Scan(&challengeDays).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]streakDay, 0, len(challengeDays))
+
+	for _, day := range challengeDays {
+		outcome := streakDayPending
+
+		switch {
+		case day.RequiredTasks == 0:
+			outcome = streakDayNeutral
+		case day.CompletedRequiredTasks == day.RequiredTasks:
+			outcome = streakDaySuccessful
+		case day.FailedRequiredTasks > 0:
+			outcome = streakDayFailed
+		}
+
+		days = append(days, streakDay{date: day.Date, outcome: outcome})
+	}
+
+	return days, nil
+}
+
+// buildStreak walks the days in order. Pending days before today count as failed, and the first pending day
+// from today on is the day the current streak has to be continued on.
+func buildStreak(challengeId, userId, taskId int64, days []streakDay, today time.Time) model.Streak {
+	return continueStreak(model.Streak{ChallengeID: challengeId, UserID: userId, TaskID: taskId}, days, today)
+}
+
+// continueStreak walks the days the way buildStreak does, starting from the counts of the streak.
+func continueStreak(streak model.Streak, days []streakDay, today time.Time) model.Streak {
+	streak.ExpiresAfter = nil
+
+	for _, day := range days {
+		outcome := day.outcome
+		if outcome == streakDayPending && day.date.Before(today) {
+			outcome = streakDayFailed
+		}
+
+		switch outcome {
+		case streakDaySuccessful:
+			streak.Current++
+			streak.Longest = max(streak.Longest, streak.Current)
+		case streakDayFailed:
+			streak.Current = 0
+		case streakDayPending:
+			date := day.date
+			streak.ExpiresAfter = &date
+			return streak
+		}
+	}
+
+	return streak
+}
+
+func toPbStreak(taskId int64, streak *model.Streak, today time.Time) *pb.Streak {
+	resp := &pb.Streak{
+		TaskId: taskId,
+	}
+
+	if streak != nil {
+		resp.Current = streak.CurrentOn(today)
+		resp.Longest = streak.Longest
+	}
+
+	return resp
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"ryg-task-service/model"
+	"testing"
+	"time"
+)
+
+func TestBuildStreak(t *testing.T) {
+	today := date(2026, time.March, 14)
+
+	day := func(d int, outcome streakOutcome) streakDay {
+		return streakDay{date: date(2026, time.March, d), outcome: outcome}
+	}
+
+	tests := []struct {
+		name             string
+		days             []streakDay
+		wantCurrent      int32
+		wantLongest      int32
+		wantExpiresAfter *time.Time
+	}{
+		{name: "no days"},
+		{
+			name:             "successful days up to today",
+			days:             []streakDay{day(12, streakDaySuccessful), day(13, streakDaySuccessful), day(14, streakDayPending)},
+			wantCurrent:      2,
+			wantLongest:      2,
+			wantExpiresAfter: &today,
+		},
+		{
+			name:             "failed day breaks the streak",
+			days:             []streakDay{day(11, streakDaySuccessful), day(12, streakDaySuccessful), day(13, streakDayFailed), day(14, streakDayPending)},
+			wantLongest:      2,
+			wantExpiresAfter: &today,
+		},
+		{
+			name:             "pending past day counts as failed",
+			days:             []streakDay{day(12, streakDaySuccessful), day(13, streakDayPending), day(14, streakDaySuccessful), day(15, streakDayPending)},
+			wantCurrent:      1,
+			wantLongest:      1,
+			wantExpiresAfter: ptr(date(2026, time.March, 15)),
+		},
+		{
+			name:             "neutral day keeps the streak",
+			days:             []streakDay{day(12, streakDaySuccessful), day(13, streakDayNeutral), day(14, streakDaySuccessful), day(15, streakDayPending)},
+			wantCurrent:      2,
+			wantLongest:      2,
+			wantExpiresAfter: ptr(date(2026, time.March, 15)),
+		},
+		{
+			name:        "every day decided",
+			days:        []streakDay{day(12, streakDaySuccessful), day(13, streakDaySuccessful)},
+			wantCurrent: 2,
+			wantLongest: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildStreak(1, 2, 3, tt.days, today)
+			want := model.Streak{ChallengeID: 1, UserID: 2, TaskID: 3, Current: tt.wantCurrent, Longest: tt.wantLongest, ExpiresAfter: tt.wantExpiresAfter}
+
+			if !sameStreak(got, want) {
+				t.Errorf("buildStreak = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestContinueStreak(t *testing.T) {
+	today := date(2026, time.March, 14)
+
+	days := []streakDay{
+		{date: date(2026, time.March, 10), outcome: streakDaySuccessful},
+		{date: date(2026, time.March, 11), outcome: streakDaySuccessful},
+		{date: date(2026, time.March, 12), outcome: streakDayFailed},
+		{date: date(2026, time.March, 13), outcome: streakDaySuccessful},
+		{date: today, outcome: streakDayPending},
+		{date: date(2026, time.March, 15), outcome: streakDayPending},
+	}
+
+	stored := buildStreak(1, 2, 3, days, today)
+
+	if stored.ExpiresAfter == nil || !stored.ExpiresAfter.Equal(today) {
+		t.Fatalf("streak expires after %v, want %v", stored.ExpiresAfter, today)
+	}
+
+	// Today gets completed, and the streak is continued from the day it stopped on.
+	days[4].outcome = streakDaySuccessful
+
+	got := continueStreak(stored, days[4:], today)
+	want := buildStreak(1, 2, 3, days, today)
+
+	if !sameStreak(got, want) {
+		t.Errorf("continued streak = %+v, want %+v", got, want)
+	}
+
+	if got.Current != 2 || got.Longest != 2 {
+		t.Errorf("current = %d, longest = %d, want 2 and 2", got.Current, got.Longest)
+	}
+}
+
+func sameStreak(a, b model.Streak) bool {
+	if a.ChallengeID != b.ChallengeID || a.UserID != b.UserID || a.TaskID != b.TaskID || a.Current != b.Current || a.Longest != b.Longest {
+		return false
+	}
+
+	if a.ExpiresAfter == nil || b.ExpiresAfter == nil {
+		return a.ExpiresAfter == b.ExpiresAfter
+	}
+
+	return a.ExpiresAfter.Equal(*b.ExpiresAfter)
+}
@@ -2,130 +2,335 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
+	"ryg-task-service/clock"
 	pb "ryg-task-service/gen_proto/task_service"
 	"ryg-task-service/model"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 )
 
+const maxTagLength = 50
+
 type TaskService struct {
 	db           *gorm.DB
+	clock        clock.Clock
 	ChallengeSvs *ChallengeService
 	pb.UnimplementedTaskServiceServer
 }
 
-func NewTaskService(db *gorm.DB) *TaskService {
+func NewTaskService(db *gorm.DB, clk clock.Clock) *TaskService {
 	return &TaskService{
-		db: db,
+		db:    db,
+		clock: clk,
 	}
 }
 
 func (s *TaskService) CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*pb.Task, error) {
-	if _, err := s.ChallengeSvs.ValidateChallengeOwnedByUser(req.ChallengeId, req.UserId); err != nil {
+	var task *model.Task
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var scheduled bool
+		var err error
+
+		task, scheduled, err = s.createTask(tx, req)
+		if err != nil || !scheduled {
+			return err
+		}
+
+		return s.refreshStreaksOfParticipants(tx, task.ChallengeID, nil)
+	})
+
+	if err != nil {
 		return nil, err
 	}
 
+	return toPbTask(task), nil
+}
+
+// createTask adds the task to the challenge, reporting whether it was scheduled. When the challenge is already
+// started, the task is scheduled for every participant on the remaining days, today included. Refreshing the
+// challenge streaks is left to the caller.
+func (s *TaskService) createTask(tx *gorm.DB, req *pb.CreateTaskRequest) (*model.Task, bool, error) {
+	challenge, err := s.ChallengeSvs.ValidateChallengeOwnedByUser(req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, false, err
+	}
+
 	if err := validateCreateTaskRequest(req); err != nil {
-		return nil, err
+		return nil, false, err
+	}
+
+	if challenge.Status == model.ChallengeStatusFinished {
+		return nil, false, status.Error(400, "Cannot add task to finished challenge")
+	}
+
+	position, err := nextTaskPosition(tx, req.ChallengeId)
+	if err != nil {
+		return nil, false, err
+	}
+
+	recurrence := recurrenceFromPb(req.Recurrence)
+
+	window, err := timeWindowFromPb(req.Window)
+	if err != nil {
+		return nil, false, err
+	}
+
+	assignees := normalizeAssignees(req.AssigneeIds)
+
+	if err := validateTaskAssignees(tx, req.ChallengeId, assignees); err != nil {
+		return nil, false, err
 	}
 
 	task := &model.Task{
 		Title:       req.Title,
 		Description: req.Description,
 		ChallengeID: req.ChallengeId,
-		WeekDays:    model.WeekDays(req.WeekDays),
+		WeekDays:    taskWeekDays(recurrence, req.WeekDays),
+		TargetValue: req.TargetValue,
+		Unit:        req.Unit,
+		Position:    position,
+		Tags:        normalizeTags(req.Tags),
+		Recurrence:  recurrence,
+		Window:      window,
+		Assignees:   assignees,
+		Required:    taskRequired(req.Required),
+		Weight:      taskWeight(req.Weight),
+	}
+
+	if err := tx.WithContext(context.Background()).Create(&task).Error; err != nil {
+		return nil, false, err
+	}
+
+	if challenge.Status != model.ChallengeStatusStarted {
+		return task, false, nil
+	}
+
+	taskAndStatuses, err := s.buildTaskAndStatusesForRemainingDays(tx, challenge, task)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(taskAndStatuses) == 0 {
+		return nil, false, status.Error(400, "Task does not fall on any of the remaining days of the challenge")
+	}
+
+	if err := createTaskAndStatuses(tx, taskAndStatuses); err != nil {
+		return nil, false, err
 	}
 
-	if err := s.db.WithContext(context.Background()).Create(&task).Error; err != nil {
+	return task, true, nil
+}
+
+// nextTaskPosition returns the position that puts a new task after every existing task of the challenge.
+func nextTaskPosition(tx *gorm.DB, challengeId int64) (int32, error) {
+	var maxPosition int32
+
+	if err := tx.Model(&model.Task{}).Where("challenge_id = ?", challengeId).Select("COALESCE(MAX(position), 0)").Scan(&maxPosition).Error; err != nil {
+		return 0, err
+	}
+
+	return maxPosition + 1, nil
+}
+
+// buildTaskAndStatusesForRemainingDays builds the statuses of the task for every participant from their today
+// until the end of the challenge. The end date of a started challenge can already be behind the participants'
+// today until the challenge is finished, which leaves no days to schedule.
+func (s *TaskService) buildTaskAndStatusesForRemainingDays(tx *gorm.DB, challenge *model.Challenge, task *model.Task) ([]model.TaskAndStatus, error) {
+	participantIdsByToday, err := s.ChallengeSvs.getParticipantIdsByToday(tx, challenge.ID)
+	if err != nil {
 		return nil, err
 	}
 
-	return &pb.Task{
-		Id:          task.ID,
-		Title:       task.Title,
-		Description: task.Description,
-		ChallengeId: task.ChallengeID,
-		WeekDays:    int32(task.WeekDays),
-	}, nil
+	taskAndStatuses := make([]model.TaskAndStatus, 0)
+	hasRemainingDays := false
+
+	for today, participantIds := range participantIdsByToday {
+		if today.Before(challenge.EndDate) {
+			hasRemainingDays = true
+		}
+
+		taskAndStatuses = append(taskAndStatuses, buildTaskAndStatuses([]model.Task{*task}, participantIds, challenge.StartDate, today, challenge.EndDate)...)
+	}
+
+	if !hasRemainingDays {
+		return nil, status.Error(400, "Challenge has no remaining days")
+	}
+
+	return taskAndStatuses, nil
 }
 
 func validateCreateTaskRequest(req *pb.CreateTaskRequest) error {
-	if req.WeekDays > (1<<6) || req.WeekDays <= 0 {
-		return status.Error(400, "Invalid week days")
+	if err := validateTaskRecurrence(recurrenceFromPb(req.Recurrence), req.WeekDays); err != nil {
+		return err
 	}
 
 	if req.Title == "" {
 		return status.Error(400, "Title is required")
 	}
 
+	if err := validateTaskTags(req.Tags); err != nil {
+		return err
+	}
+
+	if err := validateTaskWeight(req.Weight); err != nil {
+		return err
+	}
+
+	return validateTaskTarget(req.TargetValue)
+}
+
+func validateTaskWeight(weight int32) error {
+	if weight < 0 {
+		return status.Error(400, "Weight cannot be negative")
+	}
+
 	return nil
 }
 
-func (s *TaskService) createTaskAndStatusesForChallenge(tx *gorm.DB, challenge *model.Challenge, userIds []int64) error {
-	if len(userIds) == 0 {
-		return nil
+// taskRequired makes tasks required unless the request says otherwise.
+func taskRequired(required *bool) *bool {
+	if required == nil {
+		required = new(bool)
+		*required = true
 	}
 
-	tasks, err := getTasksByChallengeId(tx, challenge.ID)
+	return required
+}
 
-	if err != nil {
-		return err
+func taskWeight(weight int32) int32 {
+	if weight == 0 {
+		return 1
 	}
 
-	for date := challenge.StartDate; date.Before(challenge.EndDate); date = date.AddDate(0, 0, 1) {
-		for _, task := range tasks {
-			if !task.WeekDays.Includes(date.Weekday()) {
-				continue
-			}
+	return weight
+}
 
-			for _, userId := range userIds {
-				taskAndStatus := &model.TaskAndStatus{
-					TaskID: task.ID,
-					Date:   date,
-					Status: model.TaskStatusNotStarted,
-					UserID: userId,
-				}
-
-				if err := tx.WithContext(context.Background()).Create(&taskAndStatus).Error; err != nil {
-					return err
-				}
-			}
+func validateTaskTags(tags []string) error {
+	for _, tag := range tags {
+		if len(tag) > maxTagLength {
+			return status.Error(400, fmt.Sprintf("Tag cannot be longer than %d characters", maxTagLength))
 		}
 	}
 
 	return nil
 }
 
+// normalizeTags lowercases and trims the tags, dropping empty and repeated ones.
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
+
+// tagFilter returns the JSON array to match task tags against with the jsonb containment operator.
+func tagFilter(tag string) string {
+	filter, _ := json.Marshal(normalizeTags([]string{tag}))
+	return string(filter)
+}
+
+func validateTaskTarget(targetValue *float64) error {
+	if targetValue != nil && *targetValue <= 0 {
+		return status.Error(400, "Target value must be greater than 0")
+	}
+
+	return nil
+}
+
+// getTasksByChallengeId returns the tasks of the challenge that are not archived, in their display order.
 func getTasksByChallengeId(tx *gorm.DB, challengeId int64) ([]model.Task, error) {
 	var tasks []model.Task
 
-	if err := tx.WithContext(context.Background()).Where("challenge_id = ?", challengeId).Find(&tasks).Error; err != nil {
+	if err := tx.WithContext(context.Background()).Where("challenge_id = ? AND archived_at IS NULL", challengeId).Order("position, id").Find(&tasks).Error; err != nil {
 		return nil, err
 	}
 
 	return tasks, nil
 }
 
+// copyTasks copies every task of one challenge, with its checklist, into another that starts shiftDays later.
+// Tasks stay assigned only to the assignees who take part in the other challenge.
+func copyTasks(tx *gorm.DB, fromChallengeId, toChallengeId int64, shiftDays int) error {
+	tasks, err := getTasksByChallengeId(tx, fromChallengeId)
+	if err != nil {
+		return err
+	}
+
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	var participantIds []int64
+
+	if err := tx.Model(&model.ChallengeAndUser{}).Where("challenge_id = ?", toChallengeId).Pluck("user_id", &participantIds).Error; err != nil {
+		return err
+	}
+
+	fromTaskIds := make([]int64, 0, len(tasks))
+
+	for i := range tasks {
+		fromTaskIds = append(fromTaskIds, tasks[i].ID)
+		tasks[i].ID = 0
+		tasks[i].ChallengeID = toChallengeId
+		tasks[i].Assignees = participatingAssignees(tasks[i].Assignees, participantIds)
+		tasks[i].Recurrence = shiftRecurrenceDates(tasks[i].Recurrence, shiftDays)
+	}
+
+	if err := tx.WithContext(context.Background()).Create(&tasks).Error; err != nil {
+		return err
+	}
+
+	return copyChecklistItems(tx, fromTaskIds, tasks)
+}
+
 func (s *TaskService) CreateTasks(ctx context.Context, req *pb.CreateTasksRequest) (*pb.TaskList, error) {
 	createdTasks := make([]*pb.Task, 0)
 
 	err := s.db.Transaction(func(tx *gorm.DB) error {
+		scheduledChallengeIds := make([]int64, 0)
+
 		for _, taskReq := range req.TaskRequests {
-			if err := validateCreateTaskRequest(taskReq); err != nil {
+			task, scheduled, err := s.createTask(tx, taskReq)
+
+			if err != nil {
 				return err
 			}
 
-			createdTask, err := s.CreateTask(ctx, taskReq)
+			if scheduled && !slices.Contains(scheduledChallengeIds, task.ChallengeID) {
+				scheduledChallengeIds = append(scheduledChallengeIds, task.ChallengeID)
+			}
 
-			if err != nil {
+			createdTasks = append(createdTasks, toPbTask(task))
+		}
+
+		// The streaks are refreshed once every task is scheduled.
+		for _, challengeId := range scheduledChallengeIds {
+			if err := s.refreshStreaksOfParticipants(tx, challengeId, nil); err != nil {
 				return err
 			}
-
-			createdTasks = append(createdTasks, createdTask)
 		}
+
 		return nil
 	})
 
@@ -147,7 +352,17 @@ func (s *TaskService) GetTasksByChallengeId(ctx context.Context, req *pb.GetTask
 
 	var tasks []model.Task
 
-	if err := s.db.WithContext(context.Background()).Where("challenge_id = ?", req.ChallengeId).Find(&tasks).Error; err != nil {
+	query := s.db.WithContext(context.Background()).Where("challenge_id = ?", req.ChallengeId)
+
+	if !req.IncludeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+
+	if req.Tag != "" {
+		query = query.Where("tags @> ?::jsonb", tagFilter(req.Tag))
+	}
+
+	if err := query.Order("position, id").Find(&tasks).Error; err != nil {
 		return nil, err
 	}
 
@@ -156,13 +371,7 @@ func (s *TaskService) GetTasksByChallengeId(ctx context.Context, req *pb.GetTask
 	}
 
 	for _, task := range tasks {
-		resp.Tasks = append(resp.Tasks, &pb.Task{
-			Id:          task.ID,
-			Title:       task.Title,
-			Description: task.Description,
-			ChallengeId: task.ChallengeID,
-			WeekDays:    int32(task.WeekDays),
-		})
+		resp.Tasks = append(resp.Tasks, toPbTask(&task))
 	}
 
 	return resp, nil
@@ -175,15 +384,7 @@ func (s *TaskService) GetTaskById(ctx context.Context, req *pb.GetTaskRequest) (
 		return nil, err
 	}
 
-	resp := &pb.Task{
-		Id:          task.ID,
-		Title:       task.Title,
-		Description: task.Description,
-		ChallengeId: task.ChallengeID,
-		WeekDays:    int32(task.WeekDays),
-	}
-
-	return resp, nil
+	return toPbTask(task), nil
 }
 
 func (s *TaskService) GetTasksByChallengeIdAndDate(ctx context.Context, req *pb.GetTaskByChallengeIdAndDateRequest) (*pb.TaskWithStatusList, error) {
@@ -193,17 +394,22 @@ func (s *TaskService) GetTasksByChallengeIdAndDate(ctx context.Context, req *pb.
 		return nil, err
 	}
 
-	if challenge.Status == model.ChallengeStatusDraft {
-		return nil, status.Error(400, "Cannot get tasks for draft challenge")
+	if challenge.Status == model.ChallengeStatusDraft || challenge.Status == model.ChallengeStatusScheduled {
+		return nil, status.Error(400, "Cannot get tasks for draft or scheduled challenge")
 	}
 
 	var taskAndStatuses []model.TaskAndStatus
 
-	err = s.db.
+	query := s.db.
 		Preload("Task").
 		Joins("JOIN tasks ON tasks.id = task_and_status.task_id").
-		Where("tasks.challenge_id = ? AND task_and_status.date = ? AND task_and_status.user_id = ?", req.ChallengeId, req.Date.AsTime(), req.UserId).
-		Find(&taskAndStatuses).Error
+		Where("tasks.challenge_id = ? AND task_and_status.date = ? AND task_and_status.user_id = ?", req.ChallengeId, req.Date.AsTime(), req.UserId)
+
+	if req.Tag != "" {
+		query = query.Where("tasks.tags @> ?::jsonb", tagFilter(req.Tag))
+	}
+
+	err = query.Order("tasks.position, tasks.id").Find(&taskAndStatuses).Error
 
 	if err != nil {
 		return nil, err
@@ -214,74 +420,245 @@ func (s *TaskService) GetTasksByChallengeIdAndDate(ctx context.Context, req *pb.
 	}
 
 	for _, taskAndStatus := range taskAndStatuses {
-		resp.TaskWithStatuses = append(resp.TaskWithStatuses, &pb.TaskWithStatus{
-			Task: &pb.Task{
-				Id:          taskAndStatus.Task.ID,
-				Title:       taskAndStatus.Task.Title,
-				Description: taskAndStatus.Task.Description,
-				ChallengeId: taskAndStatus.Task.ChallengeID,
-				WeekDays:    int32(taskAndStatus.Task.WeekDays),
-			},
-			Date:   timestamppb.New(taskAndStatus.Date),
-			Status: string(taskAndStatus.Status),
-		})
+		resp.TaskWithStatuses = append(resp.TaskWithStatuses, toPbTaskWithStatus(&taskAndStatus))
 	}
 
 	return resp, nil
 }
 
 func (s *TaskService) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb.Task, error) {
-	task, err := s.validateUpdateTaskRequest(req)
+	task, challenge, err := s.validateUpdateTaskRequest(req)
 
 	if err != nil {
 		return nil, err
 	}
 
-	task.Title = req.Title
-	task.Description = req.Description
-
-	if err := s.db.WithContext(context.Background()).Save(&task).Error; err != nil {
+	updated, err := s.buildTaskUpdate(req, task, challenge)
+	if err != nil {
 		return nil, err
 	}
 
-	resp := &pb.Task{
-		Id:          task.ID,
-		Title:       task.Title,
-		Description: task.Description,
-		ChallengeId: task.ChallengeID,
+	assigneesChanged := !sameAssignees(task.Assignees, updated.Assignees)
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.WithContext(context.Background()).Save(&updated).Error; err != nil {
+			return err
+		}
+
+		if !assigneesChanged {
+			return nil
+		}
+
+		return s.syncAssignedTaskStatuses(tx, challenge, updated, req.UserId)
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	return resp, nil
+	return toPbTask(updated), nil
 }
 
-func (s *TaskService) validateUpdateTaskRequest(req *pb.UpdateTaskRequest) (*model.Task, error) {
+func (s *TaskService) validateUpdateTaskRequest(req *pb.UpdateTaskRequest) (*model.Task, *model.Challenge, error) {
 	task, err := s.validateTaskOwnedByUser(req.Id, req.ChallengeId, req.UserId)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	challenge, err := s.ChallengeSvs.ValidateChallengeOwnedByUser(req.ChallengeId, req.UserId)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if challenge.Status == model.ChallengeStatusFinished {
-		return nil, status.Error(400, "Cannot update task for finished challenge")
+		return nil, nil, status.Error(400, "Cannot update task for finished challenge")
+	}
+
+	if task.ArchivedAt != nil {
+		return nil, nil, status.Error(400, "Cannot update archived task")
+	}
+
+	return task, challenge, nil
+}
+
+// buildTaskUpdate returns the task with the fields of the update mask taken from the request, once they are validated.
+func (s *TaskService) buildTaskUpdate(req *pb.UpdateTaskRequest, task *model.Task, challenge *model.Challenge) (*model.Task, error) {
+	fields, err := updateTaskFields(req.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *task
+
+	if fields["title"] {
+		if req.Title == "" {
+			return nil, status.Error(400, "Title is required")
+		}
+
+		updated.Title = req.Title
+	}
+
+	if fields["description"] {
+		updated.Description = req.Description
+	}
+
+	if fields["week_days"] || fields["recurrence"] {
+		recurrence := task.Recurrence
+		if fields["recurrence"] {
+			recurrence = recurrenceFromPb(req.Recurrence)
+		}
+
+		weekDays := int32(task.WeekDays)
+		if fields["week_days"] {
+			weekDays = req.WeekDays
+		}
+
+		if err := validateTaskRecurrence(recurrence, weekDays); err != nil {
+			return nil, err
+		}
+
+		updated.Recurrence = recurrence
+		updated.WeekDays = taskWeekDays(recurrence, weekDays)
+	}
+
+	if fields["window"] {
+		if updated.Window, err = timeWindowFromPb(req.Window); err != nil {
+			return nil, err
+		}
+	}
+
+	if fields["target_value"] {
+		if err := s.validateTaskTargetUpdate(task, req.TargetValue); err != nil {
+			return nil, err
+		}
+
+		updated.TargetValue = req.TargetValue
 	}
 
-	if task.WeekDays != model.WeekDays(req.WeekDays) && challenge.Status == model.ChallengeStatusStarted {
+	if fields["unit"] {
+		updated.Unit = req.Unit
+	}
+
+	if fields["tags"] {
+		if err := validateTaskTags(req.Tags); err != nil {
+			return nil, err
+		}
+
+		updated.Tags = normalizeTags(req.Tags)
+	}
+
+	if fields["assignee_ids"] {
+		assignees := normalizeAssignees(req.AssigneeIds)
+
+		if err := validateTaskAssignees(s.db, req.ChallengeId, assignees); err != nil {
+			return nil, err
+		}
+
+		updated.Assignees = assignees
+	}
+
+	if fields["required"] {
+		updated.Required = taskRequired(req.Required)
+	}
+
+	if fields["weight"] {
+		if err := validateTaskWeight(req.Weight); err != nil {
+			return nil, err
+		}
+
+		updated.Weight = taskWeight(req.Weight)
+	}
+
+	if challenge.Status != model.ChallengeStatusStarted && challenge.Status != model.ChallengeStatusPaused {
+		return &updated, nil
+	}
+
+	if task.WeekDays != updated.WeekDays || !task.Recurrence.Equal(updated.Recurrence) {
 		return nil, status.Error(400, "Cannot update task days for started challenge")
 	}
 
-	if req.Title == "" {
-		return nil, status.Error(400, "Title is required")
+	if !sameTarget(task.TargetValue, updated.TargetValue) {
+		return nil, status.Error(400, "Cannot update task target for started challenge")
+	}
+
+	if task.IsRequired() != updated.IsRequired() || task.Weight != updated.Weight {
+		return nil, status.Error(400, "Cannot update task scoring for started challenge")
 	}
 
-	return task, nil
+	return &updated, nil
 }
 
+// defaultUpdateTaskFields are the fields UpdateTask changes for requests without an update mask, the ones tasks had
+// before any other was added.
+var defaultUpdateTaskFields = []string{"title", "description", "week_days"}
+
+var updatableTaskFields = map[string]bool{
+	"title":        true,
+	"description":  true,
+	"week_days":    true,
+	"recurrence":   true,
+	"window":       true,
+	"target_value": true,
+	"unit":         true,
+	"tags":         true,
+	"assignee_ids": true,
+	"required":     true,
+	"weight":       true,
+}
+
+func updateTaskFields(mask *fieldmaskpb.FieldMask) (map[string]bool, error) {
+	paths := defaultUpdateTaskFields
+	if len(mask.GetPaths()) > 0 {
+		paths = mask.GetPaths()
+	}
+
+	fields := make(map[string]bool, len(paths))
+
+	for _, path := range paths {
+		if !updatableTaskFields[path] {
+			return nil, status.Error(400, fmt.Sprintf("Field %s cannot be updated", path))
+		}
+
+		fields[path] = true
+	}
+
+	return fields, nil
+}
+
+// validateTaskTargetUpdate checks the new target of the task. Tasks with a checklist cannot become quantitative.
+func (s *TaskService) validateTaskTargetUpdate(task *model.Task, targetValue *float64) error {
+	if err := validateTaskTarget(targetValue); err != nil {
+		return err
+	}
+
+	if targetValue == nil || task.IsQuantitative() {
+		return nil
+	}
+
+	var checklistItems int64
+
+	if err := s.db.Model(&model.ChecklistItem{}).Where("task_id = ?", task.ID).Count(&checklistItems).Error; err != nil {
+		return err
+	}
+
+	if checklistItems > 0 {
+		return status.Error(400, "Task with a checklist cannot have a target")
+	}
+
+	return nil
+}
+
+func sameTarget(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// DeleteTask archives the task. Its statuses up to today are kept, while the ones still ahead are removed.
 func (s *TaskService) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*emptypb.Empty, error) {
 	task, err := s.validateTaskOwnedByUser(req.Id, req.ChallengeId, req.UserId)
 
@@ -289,13 +666,159 @@ func (s *TaskService) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest)
 		return nil, err
 	}
 
-	if err := s.db.WithContext(context.Background()).Delete(&task).Error; err != nil {
+	if task.ArchivedAt != nil {
+		return nil, status.Error(400, "Task is already archived")
+	}
+
+	now := s.clock.Now()
+	task.ArchivedAt = &now
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.WithContext(context.Background()).Save(&task).Error; err != nil {
+			return err
+		}
+
+		if err := s.deleteRemainingTaskStatuses(tx, task.ChallengeID, []int64{task.ID}); err != nil {
+			return err
+		}
+
+		return s.refreshStreaksOfParticipants(tx, task.ChallengeID, nil)
+	})
+
+	if err != nil {
 		return nil, err
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
+func (s *TaskService) RestoreTask(ctx context.Context, req *pb.RestoreTaskRequest) (*pb.Task, error) {
+	task, err := s.validateTaskOwnedByUser(req.Id, req.ChallengeId, req.UserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if task.ArchivedAt == nil {
+		return nil, status.Error(400, "Task is not archived")
+	}
+
+	challenge, err := s.ChallengeSvs.ValidateChallengeOwnedByUser(req.ChallengeId, req.UserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if challenge.Status == model.ChallengeStatusFinished {
+		return nil, status.Error(400, "Cannot restore task for finished challenge")
+	}
+
+	task.ArchivedAt = nil
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.WithContext(context.Background()).Save(&task).Error; err != nil {
+			return err
+		}
+
+		if challenge.Status != model.ChallengeStatusStarted {
+			return nil
+		}
+
+		taskAndStatuses, err := s.buildTaskAndStatusesForRemainingDays(tx, challenge, task)
+		if err != nil {
+			return err
+		}
+
+		if err := createTaskAndStatuses(tx, taskAndStatuses); err != nil {
+			return err
+		}
+
+		return s.refreshStreaksOfParticipants(tx, challenge.ID, []int64{task.ID})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toPbTask(task), nil
+}
+
+// ReorderTasks rewrites the positions of the challenge tasks to follow the order of the given ids.
+// Every task of the challenge that is not archived has to be listed exactly once.
+func (s *TaskService) ReorderTasks(ctx context.Context, req *pb.ReorderTasksRequest) (*pb.TaskList, error) {
+	if _, err := s.ChallengeSvs.ValidateChallengeOwnedByUser(req.ChallengeId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	var tasks []model.Task
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		tasks, err = getTasksByChallengeId(tx, req.ChallengeId)
+		if err != nil {
+			return err
+		}
+
+		if err := validateReorderTasksRequest(req, tasks); err != nil {
+			return err
+		}
+
+		positions := make(map[int64]int32, len(req.TaskIds))
+		for i, taskId := range req.TaskIds {
+			positions[taskId] = int32(i + 1)
+		}
+
+		for i := range tasks {
+			tasks[i].Position = positions[tasks[i].ID]
+
+			if err := tx.WithContext(context.Background()).Model(&tasks[i]).Update("position", tasks[i].Position).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].Position < tasks[j].Position
+	})
+
+	resp := &pb.TaskList{
+		Tasks: make([]*pb.Task, 0),
+	}
+
+	for i := range tasks {
+		resp.Tasks = append(resp.Tasks, toPbTask(&tasks[i]))
+	}
+
+	return resp, nil
+}
+
+func validateReorderTasksRequest(req *pb.ReorderTasksRequest, tasks []model.Task) error {
+	if len(req.TaskIds) != len(tasks) {
+		return status.Error(400, "Every task of the challenge must be listed exactly once")
+	}
+
+	taskIds := make(map[int64]bool, len(tasks))
+	for _, task := range tasks {
+		taskIds[task.ID] = true
+	}
+
+	for _, taskId := range req.TaskIds {
+		if !taskIds[taskId] {
+			return status.Error(400, "Every task of the challenge must be listed exactly once")
+		}
+
+		delete(taskIds, taskId)
+	}
+
+	return nil
+}
+
 func (s *TaskService) validateTaskOwnedByUser(taskId, challengeId, userId int64) (*model.Task, error) {
 	if _, err := s.ChallengeSvs.ValidateChallengeOwnedByUser(challengeId, userId); err != nil {
 		return nil, err
@@ -331,11 +854,17 @@ func (s *TaskService) validateUserCanReadTask(taskId, challengeId, userId int64)
 }
 
 func (s *TaskService) UpdateTaskStatus(ctx context.Context, req *pb.UpdateTaskStatusRequest) (*pb.TaskWithStatus, error) {
-	if _, err := s.validateUserCanReadTask(req.TaskId, req.ChallengeId, req.UserId); err != nil {
+	task, err := s.validateUserCanReadTask(req.TaskId, req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateTaskAssignedToUser(task, req.UserId); err != nil {
 		return nil, err
 	}
 
-	if err := s.validateUpdateTaskStatusRequest(req); err != nil {
+	newStatus, err := s.validateUpdateTaskStatusRequest(req)
+	if err != nil {
 		return nil, err
 	}
 
@@ -345,46 +874,207 @@ func (s *TaskService) UpdateTaskStatus(ctx context.Context, req *pb.UpdateTaskSt
 		return nil, err
 	}
 
-	taskAndStatus.Status = model.TaskStatus(req.Status)
+	if err := s.validateTaskStatusChange(&taskAndStatus, task, newStatus); err != nil {
+		return nil, err
+	}
 
-	if err := s.db.WithContext(context.Background()).Save(&taskAndStatus).Error; err != nil {
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		return s.saveTaskStatus(tx, task.ChallengeID, &taskAndStatus, newStatus, req.UserId)
+	})
+
+	if err != nil {
 		return nil, err
 	}
 
-	return &pb.TaskWithStatus{
-		Task: &pb.Task{
-			Id:          taskAndStatus.TaskID,
-			Title:       taskAndStatus.Task.Title,
-			Description: taskAndStatus.Task.Description,
-			ChallengeId: taskAndStatus.Task.ChallengeID,
-			WeekDays:    int32(taskAndStatus.Task.WeekDays),
-		},
-		Date:   timestamppb.New(taskAndStatus.Date),
-		Status: string(taskAndStatus.Status),
-	}, nil
+	taskAndStatus.Task = *task
+
+	return toPbTaskWithStatus(&taskAndStatus), nil
 }
 
-func (s *TaskService) validateUpdateTaskStatusRequest(req *pb.UpdateTaskStatusRequest) error {
-	if req.Status == "" {
-		return status.Error(400, "Status is required")
+// validateUpdateTaskStatusRequest returns the requested status.
+func (s *TaskService) validateUpdateTaskStatusRequest(req *pb.UpdateTaskStatusRequest) (model.TaskStatus, error) {
+	newStatus, err := taskStatusFromRequest(req.TaskStatus, req.Status)
+	if err != nil {
+		return "", err
 	}
 
-	today := time.Now().Truncate(24 * time.Hour)
-	requestDate := req.Date.AsTime().Truncate(24 * time.Hour)
+	return newStatus, s.validateTaskStatusDate(req.ChallengeId, req.UserId, req.Date)
+}
 
-	if !requestDate.Equal(today) {
-		return status.Error(400, "Date should be today")
+// validateTaskStatusChange checks that the status may be set to newStatus, and flags it as late when it completes
+// the task after its time window. The status of quantitative tasks is derived from their progress, so they can only
+// be set to a neutral status directly.
+func (s *TaskService) validateTaskStatusChange(taskAndStatus *model.TaskAndStatus, task *model.Task, newStatus model.TaskStatus) error {
+	if task.ArchivedAt != nil {
+		return status.Error(400, "Cannot update status of archived task")
 	}
 
-	challenge, err := s.ChallengeSvs.ValidateUserCanReadChallenge(req.ChallengeId, req.UserId)
+	if task.IsQuantitative() && !newStatus.IsNeutral() {
+		return status.Error(400, "Status of quantitative task is derived from its progress")
+	}
+
+	if err := validateTaskStatusTransition(taskAndStatus.Status, newStatus); err != nil {
+		return err
+	}
+
+	return s.applyTaskWindow(taskAndStatus, task, newStatus)
+}
 
+// UpdateTaskProgress records the progress made on a quantitative task and derives its status from the target.
+func (s *TaskService) UpdateTaskProgress(ctx context.Context, req *pb.UpdateTaskProgressRequest) (*pb.TaskWithStatus, error) {
+	task, err := s.validateUserCanReadTask(req.TaskId, req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateUpdateTaskProgressRequest(req, task); err != nil {
+		return nil, err
+	}
+
+	var taskAndStatus model.TaskAndStatus
+
+	if err := s.db.WithContext(context.Background()).First(&taskAndStatus, "task_id = ? AND date = ? AND user_id = ?", req.TaskId, req.Date.AsTime(), req.UserId).Error; err != nil {
+		return nil, err
+	}
+
+	newStatus := task.StatusForProgress(req.Progress)
+
+	if err := s.applyTaskWindow(&taskAndStatus, task, newStatus); err != nil {
+		return nil, err
+	}
+
+	taskAndStatus.Progress = req.Progress
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		return s.saveTaskStatus(tx, task.ChallengeID, &taskAndStatus, newStatus, req.UserId)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	taskAndStatus.Task = *task
+
+	return toPbTaskWithStatus(&taskAndStatus), nil
+}
+
+func (s *TaskService) validateUpdateTaskProgressRequest(req *pb.UpdateTaskProgressRequest, task *model.Task) error {
+	if task.ArchivedAt != nil {
+		return status.Error(400, "Cannot update progress of archived task")
+	}
+
+	if !task.IsQuantitative() {
+		return status.Error(400, "Task has no target to report progress against")
+	}
+
+	if req.Progress < 0 {
+		return status.Error(400, "Progress cannot be negative")
+	}
+
+	if err := validateTaskAssignedToUser(task, req.UserId); err != nil {
+		return err
+	}
+
+	return s.validateTaskStatusDate(req.ChallengeId, req.UserId, req.Date)
+}
+
+// applyTaskWindow flags the status as late when it completes the task after the task's time window.
+// Completing a task before its window opens is rejected. The participant's local time is used.
+func (s *TaskService) applyTaskWindow(taskAndStatus *model.TaskAndStatus, task *model.Task, newStatus model.TaskStatus) error {
+	if newStatus != model.TaskStatusCompleted {
+		taskAndStatus.Late = false
+		return nil
+	}
+
+	// A task that stays completed keeps the time it was first completed at.
+	if taskAndStatus.Status == model.TaskStatusCompleted {
+		return nil
+	}
+
+	now, err := s.ChallengeSvs.userNow(task.ChallengeID, taskAndStatus.UserID)
+	if err != nil {
+		return err
+	}
+
+	// Completing a past day, during the grace period or through a correction, is always past the window.
+	if taskAndStatus.Date.Before(calendarDay(now, now.Location())) {
+		taskAndStatus.Late = task.Window.IsSet()
+		return nil
+	}
+
+	late, err := isLateCompletion(task.Window, now)
+	if err != nil {
+		return err
+	}
+
+	taskAndStatus.Late = late
+
+	return nil
+}
+
+// validateTaskStatusDate checks that the user may change their task statuses for the given date,
+// which is today or one of the past days of the grace period of the challenge.
+func (s *TaskService) validateTaskStatusDate(challengeId, userId int64, date *timestamppb.Timestamp) error {
+	today, err := s.ChallengeSvs.userToday(challengeId, userId)
 	if err != nil {
 		return err
 	}
 
+	challenge, err := s.ChallengeSvs.ValidateUserCanReadChallenge(challengeId, userId)
+
+	if err != nil {
+		return err
+	}
+
+	requestDate := date.AsTime().Truncate(24 * time.Hour)
+
+	if requestDate.After(today) {
+		return status.Error(400, "Cannot update task status for a future date")
+	}
+
+	if requestDate.Before(s.ChallengeSvs.graceStart(challenge, today)) {
+		return status.Error(400, "Date is outside the grace period, request a correction instead")
+	}
+
 	if challenge.Status != model.ChallengeStatusStarted {
 		return status.Error(400, "Cannot update task status for not started or finished challenge")
 	}
 
 	return nil
 }
+
+func toPbTask(task *model.Task) *pb.Task {
+	resp := &pb.Task{
+		Id:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		ChallengeId: task.ChallengeID,
+		WeekDays:    int32(task.WeekDays),
+		TargetValue: task.TargetValue,
+		Unit:        task.Unit,
+		Position:    task.Position,
+		Tags:        task.Tags,
+		Recurrence:  toPbRecurrence(task.Recurrence),
+		Window:      toPbTimeWindow(task.Window),
+		AssigneeIds: task.Assignees,
+		Required:    task.IsRequired(),
+		Weight:      task.Weight,
+	}
+
+	if task.ArchivedAt != nil {
+		resp.ArchivedAt = timestamppb.New(*task.ArchivedAt)
+	}
+
+	return resp
+}
+
+func toPbTaskWithStatus(taskAndStatus *model.TaskAndStatus) *pb.TaskWithStatus {
+	return &pb.TaskWithStatus{
+		Task:       toPbTask(&taskAndStatus.Task),
+		Date:       timestamppb.New(taskAndStatus.Date),
+		Status:     string(taskAndStatus.Status),
+		TaskStatus: toPbTaskStatus(taskAndStatus.Status),
+		Progress:   taskAndStatus.Progress,
+		Late:       taskAndStatus.Late,
+	}
+}
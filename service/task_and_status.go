@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"ryg-task-service/model"
+	"time"
+)
+
+// taskAndStatusBatchSize is the number of task statuses inserted with a single statement.
+// It keeps every statement well below the 65535 bind parameters Postgres accepts.
+const taskAndStatusBatchSize = 5000
+
+func (s *TaskService) createTaskAndStatusesForChallenge(tx *gorm.DB, challenge *model.Challenge, userIds []int64) error {
+	if len(userIds) == 0 {
+		return nil
+	}
+
+	tasks, err := getTasksByChallengeId(tx, challenge.ID)
+
+	if err != nil {
+		return err
+	}
+
+	return createTaskAndStatuses(tx, buildTaskAndStatuses(tasks, userIds, challenge.StartDate, challenge.StartDate, challenge.EndDate))
+}
+
+// buildTaskAndStatuses returns a not started status for every user assigned to each task,
+// on every day in [from, to) that the task is scheduled for. An empty range builds no statuses.
+func buildTaskAndStatuses(tasks []model.Task, userIds []int64, challengeStart, from, to time.Time) []model.TaskAndStatus {
+	if !from.Before(to) {
+		return []model.TaskAndStatus{}
+	}
+
+	dates := make([]time.Time, 0, int(to.Sub(from).Hours()/24))
+	for date := from; date.Before(to); date = date.AddDate(0, 0, 1) {
+		dates = append(dates, date)
+	}
+
+	taskAndStatuses := make([]model.TaskAndStatus, 0, len(dates)*len(tasks)*len(userIds))
+
+	for _, task := range tasks {
+		for _, date := range dates {
+			if !task.IsDueOn(date, challengeStart) {
+				continue
+			}
+
+			for _, userId := range userIds {
+				if !task.IsAssignedTo(userId) {
+					continue
+				}
+
+				taskAndStatuses = append(taskAndStatuses, model.TaskAndStatus{
+					TaskID: task.ID,
+					Date:   date,
+					Status: model.TaskStatusNotStarted,
+					UserID: userId,
+				})
+			}
+		}
+	}
+
+	return taskAndStatuses
+}
+
+// createTaskAndStatuses inserts the task statuses in batches. Statuses that already exist are
+// left untouched, so the same days can safely be generated again.
+func createTaskAndStatuses(tx *gorm.DB, taskAndStatuses []model.TaskAndStatus) error {
+	if len(taskAndStatuses) == 0 {
+		return nil
+	}
+
+	return tx.WithContext(context.Background()).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(&taskAndStatuses, taskAndStatusBatchSize).Error
+}
+
+// markMissedTaskStatuses marks every not started task status of the challenge dated before the given day as not completed.
+// TIMES_PER_WEEK tasks have their weeks settled against their quota instead. The changes are recorded in the status
+// history without an actor, and the streaks of the challenge are refreshed.
+func (s *TaskService) markMissedTaskStatuses(tx *gorm.DB, challengeId int64, before time.Time) error {
+	dailyTaskIds := challengeTaskIds(tx, challengeId).Where("recurrence_type <> ?", model.RecurrenceTimesPerWeek)
+
+	err := tx.WithContext(context.Background()).Exec(
+		"INSERT INTO task_status_changes (task_id, user_id, date, old_status, new_status, changed_at) "+
+			"SELECT task_id, user_id, date, status, ?, ? FROM task_and_status WHERE task_id IN (?) AND status = ? AND date < ?",
+		model.TaskStatusNotCompleted, s.clock.Now(), dailyTaskIds, model.TaskStatusNotStarted, before).Error
+
+	if err != nil {
+		return err
+	}
+
+	err = tx.WithContext(context.Background()).
+		Model(&model.TaskAndStatus{}).
+		Where("task_id IN (?) AND status = ? AND date < ?", dailyTaskIds, model.TaskStatusNotStarted, before).
+		Update("status", model.TaskStatusNotCompleted).Error
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.settleWeeklyTaskStatuses(tx, challengeId, before); err != nil {
+		return err
+	}
+
+	return s.refreshChallengeStreaks(tx, challengeId)
+}
+
+// deleteRemainingTaskStatuses deletes the not started statuses of the given tasks dated on or after the today of
+// each participant of the challenge, leaving the days they have already lived untouched. taskIds can be a slice of
+// ids or a subquery selecting them.
+func (s *TaskService) deleteRemainingTaskStatuses(tx *gorm.DB, challengeId int64, taskIds interface{}) error {
+	participantIdsByToday, err := s.ChallengeSvs.getParticipantIdsByToday(tx, challengeId)
+	if err != nil {
+		return err
+	}
+
+	for today, participantIds := range participantIdsByToday {
+		err := tx.WithContext(context.Background()).
+			Where("task_id IN (?) AND user_id IN ? AND status = ? AND date >= ?", taskIds, participantIds, model.TaskStatusNotStarted, today).
+			Delete(&model.TaskAndStatus{}).Error
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// challengeTaskIds returns a subquery selecting the ids of every task of the challenge.
+func challengeTaskIds(tx *gorm.DB, challengeId int64) *gorm.DB {
+	return tx.Model(&model.Task{}).Select("id").Where("challenge_id = ?", challengeId)
+}
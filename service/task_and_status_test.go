@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"io"
+	"ryg-task-service/clock"
+	"ryg-task-service/model"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	benchmarkChallengeDays = 100
+	benchmarkUsers         = 50
+)
+
+var benchmarkTaskCounts = []int{5, 20, 50}
+
+func benchmarkTasks(challengeId int64, count int) []model.Task {
+	tasks := make([]model.Task, 0, count)
+
+	for i := 0; i < count; i++ {
+		tasks = append(tasks, model.Task{
+			ID:          int64(i + 1),
+			Title:       fmt.Sprintf("Task %d", i+1),
+			ChallengeID: challengeId,
+			WeekDays:    127,
+		})
+	}
+
+	return tasks
+}
+
+func benchmarkUserIds() []int64 {
+	userIds := make([]int64, 0, benchmarkUsers)
+
+	for i := 0; i < benchmarkUsers; i++ {
+		userIds = append(userIds, int64(i+1))
+	}
+
+	return userIds
+}
+
+func BenchmarkBuildTaskAndStatuses(b *testing.B) {
+	start := date(2026, time.March, 2)
+	end := start.AddDate(0, 0, benchmarkChallengeDays)
+	userIds := benchmarkUserIds()
+
+	for _, taskCount := range benchmarkTaskCounts {
+		tasks := benchmarkTasks(1, taskCount)
+
+		b.Run(fmt.Sprintf("tasks=%d", taskCount), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				buildTaskAndStatuses(tasks, userIds, start, start, end)
+			}
+		})
+	}
+}
+
+// taskAndStatusInserts are the ways of inserting the statuses of a challenge that are compared.
+var taskAndStatusInserts = []struct {
+	name   string
+	create func(tx *gorm.DB, taskAndStatuses []model.TaskAndStatus) error
+}{
+	{name: "batched", create: createTaskAndStatuses},
+	{name: "row by row", create: func(tx *gorm.DB, taskAndStatuses []model.TaskAndStatus) error {
+		for i := range taskAndStatuses {
+			if err := tx.Create(&taskAndStatuses[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}},
+}
+
+// BenchmarkCreateTaskAndStatuses compares the batched insert of the statuses of a challenge with inserting them
+// one row at a time. The statements go to a driver that only counts them, so the benchmark measures building and
+// sending the statements, and reports the round trips each way takes to the database.
+func BenchmarkCreateTaskAndStatuses(b *testing.B) {
+	start := date(2026, time.March, 2)
+	end := start.AddDate(0, 0, benchmarkChallengeDays)
+	userIds := benchmarkUserIds()
+
+	gdb, err := gorm.Open(postgres.New(postgres.Config{DriverName: roundTripCountingDriverName}), &gorm.Config{
+		Logger:               logger.Default.LogMode(logger.Silent),
+		DisableAutomaticPing: true,
+	})
+
+	if err != nil {
+		b.Fatalf("open the counting driver: %v", err)
+	}
+
+	for _, taskCount := range benchmarkTaskCounts {
+		taskAndStatuses := buildTaskAndStatuses(benchmarkTasks(1, taskCount), userIds, start, start, end)
+
+		for _, insert := range taskAndStatusInserts {
+			b.Run(fmt.Sprintf("tasks=%d/%s", taskCount, insert.name), func(b *testing.B) {
+				b.ReportAllocs()
+				roundTrips.Store(0)
+
+				for i := 0; i < b.N; i++ {
+					if err := insert.create(gdb, taskAndStatuses); err != nil {
+						b.Fatalf("create task statuses: %v", err)
+					}
+				}
+
+				b.ReportMetric(float64(roundTrips.Load())/float64(b.N), "roundtrips/op")
+			})
+		}
+	}
+}
+
+// BenchmarkCreateTaskAndStatusesPostgres runs the inserts of BenchmarkCreateTaskAndStatuses against
+// the database of TEST_DATABASE_DSN.
+func BenchmarkCreateTaskAndStatusesPostgres(b *testing.B) {
+	start := date(2026, time.March, 2)
+	end := start.AddDate(0, 0, benchmarkChallengeDays)
+	userIds := benchmarkUserIds()
+
+	for _, taskCount := range benchmarkTaskCounts {
+		for _, insert := range taskAndStatusInserts {
+			b.Run(fmt.Sprintf("tasks=%d/%s", taskCount, insert.name), func(b *testing.B) {
+				challengeService, _ := newTestServices(b, clock.NewFakeClock(start))
+
+				challenge := createTestChallenge(b, challengeService, benchmarkChallengeDays, "")
+				tasks := benchmarkTasks(challenge.Id, taskCount)
+
+				if err := challengeService.db.Create(&tasks).Error; err != nil {
+					b.Fatalf("create tasks: %v", err)
+				}
+
+				taskAndStatuses := buildTaskAndStatuses(tasks, userIds, start, start, end)
+
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					if err := challengeService.db.Where("1 = 1").Delete(&model.TaskAndStatus{}).Error; err != nil {
+						b.Fatalf("delete task statuses: %v", err)
+					}
+					b.StartTimer()
+
+					if err := insert.create(challengeService.db, taskAndStatuses); err != nil {
+						b.Fatalf("create task statuses: %v", err)
+					}
+				}
+			})
+		}
+	}
+}
+
+const roundTripCountingDriverName = "round-trip-counting"
+
+// roundTrips is the number of statements, transaction begins and commits the counting driver has received.
+var roundTrips atomic.Int64
+
+func init() {
+	sql.Register(roundTripCountingDriverName, roundTripCountingDriver{})
+}
+
+// roundTripCountingDriver is a database/sql driver that accepts every statement without a database
+// behind it, returning no rows, and counts the round trips a real database would take.
+type roundTripCountingDriver struct{}
+
+func (roundTripCountingDriver) Open(string) (driver.Conn, error) {
+	return roundTripCountingConn{}, nil
+}
+
+type roundTripCountingConn struct{}
+
+func (roundTripCountingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepared statements are not supported")
+}
+
+func (roundTripCountingConn) Close() error {
+	return nil
+}
+
+func (roundTripCountingConn) Begin() (driver.Tx, error) {
+	roundTrips.Add(1)
+	return roundTripCountingTx{}, nil
+}
+
+func (roundTripCountingConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	roundTrips.Add(1)
+	return driver.RowsAffected(0), nil
+}
+
+func (roundTripCountingConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	roundTrips.Add(1)
+	return noRows{}, nil
+}
+
+type roundTripCountingTx struct{}
+
+func (roundTripCountingTx) Commit() error {
+	roundTrips.Add(1)
+	return nil
+}
+
+func (roundTripCountingTx) Rollback() error {
+	roundTrips.Add(1)
+	return nil
+}
+
+type noRows struct{}
+
+func (noRows) Columns() []string {
+	return nil
+}
+
+func (noRows) Close() error {
+	return nil
+}
+
+func (noRows) Next([]driver.Value) error {
+	return io.EOF
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"fmt"
+	"google.golang.org/grpc/status"
+	pb "ryg-task-service/gen_proto/task_service"
+	"ryg-task-service/model"
+)
+
+// taskStatusFromPb converts the status of a request, rejecting unknown and unset statuses.
+func taskStatusFromPb(taskStatus pb.TaskStatus) (model.TaskStatus, error) {
+	name, ok := pb.TaskStatus_name[int32(taskStatus)]
+
+	if !ok || taskStatus == pb.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		return "", status.Error(400, "Invalid status")
+	}
+
+	return model.TaskStatus(name), nil
+}
+
+// taskStatusFromRequest converts the status of a request that also accepts the name of the status from clients
+// that predate the enum. The enum is used when it is set.
+func taskStatusFromRequest(taskStatus pb.TaskStatus, name string) (model.TaskStatus, error) {
+	if taskStatus != pb.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		return taskStatusFromPb(taskStatus)
+	}
+
+	if !model.TaskStatus(name).IsValid() {
+		return "", status.Error(400, "Invalid status")
+	}
+
+	return model.TaskStatus(name), nil
+}
+
+func toPbTaskStatus(taskStatus model.TaskStatus) pb.TaskStatus {
+	return pb.TaskStatus(pb.TaskStatus_value[string(taskStatus)])
+}
+
+func validateTaskStatusTransition(from, to model.TaskStatus) error {
+	if !from.CanTransitionTo(to) {
+		return status.Error(400, fmt.Sprintf("Cannot change task status from %s to %s", from, to))
+	}
+
+	return nil
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"ryg-task-service/clock"
+	pb "ryg-task-service/gen_proto/task_service"
+	"ryg-task-service/model"
+	"testing"
+	"time"
+)
+
+func TestValidateTaskStatusDate(t *testing.T) {
+	// The challenge starts on the 10th of March and is checked on the 12th, with a grace period of one day.
+	now := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		start   bool
+		pause   bool
+		date    time.Time
+		wantErr string
+	}{
+		{name: "today", start: true, date: date(2026, time.March, 12)},
+		{name: "inside the grace period", start: true, date: date(2026, time.March, 11)},
+		{
+			name:    "before the grace period",
+			start:   true,
+			date:    date(2026, time.March, 10),
+			wantErr: "Date is outside the grace period, request a correction instead",
+		},
+		{
+			name:    "future date",
+			start:   true,
+			date:    date(2026, time.March, 13),
+			wantErr: "Cannot update task status for a future date",
+		},
+		{
+			name:    "draft challenge",
+			date:    date(2026, time.March, 12),
+			wantErr: "Cannot update task status for not started or finished challenge",
+		},
+		{
+			name:    "paused challenge",
+			start:   true,
+			pause:   true,
+			date:    date(2026, time.March, 12),
+			wantErr: "Cannot update task status for not started or finished challenge",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewFakeClock(now)
+			challengeService, taskService := newTestServices(t, clk)
+
+			challenge := createTestChallenge(t, challengeService, 5, "")
+
+			if tt.start {
+				startTestChallenge(t, challengeService, challenge.Id)
+			}
+
+			if tt.pause {
+				pauseTestChallenge(t, challengeService, challenge.Id)
+			}
+
+			clk.Advance(2 * 24 * time.Hour)
+
+			err := taskService.validateTaskStatusDate(challenge.Id, testOwnerId, timestamppb.New(tt.date))
+
+			if got := errorMessage(err); got != tt.wantErr {
+				t.Errorf("error = %q, want %q", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateTaskInRunningChallenge(t *testing.T) {
+	// The five day challenge of an owner in UTC starts on Tuesday the 10th of March at 03:00 and ends on the 15th.
+	// The task is due every day but Saturday.
+	now := time.Date(2026, time.March, 10, 3, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		// participantTimeZone adds a participant in the time zone to the challenge.
+		participantTimeZone string
+		// elapsedDays is the number of days since the start when the task is added.
+		elapsedDays int
+		// pause pauses the challenge before the task is added and resumes it resumeDays later.
+		pause                bool
+		resumeDays           int
+		wantErr              string
+		wantDates            []time.Time
+		wantParticipantDates []time.Time
+	}{
+		{
+			name:        "started challenge",
+			elapsedDays: 2,
+			wantDates:   []time.Time{date(2026, time.March, 12), date(2026, time.March, 13)},
+		},
+		{
+			name:                 "started challenge with a participant still on the day before",
+			participantTimeZone:  "America/Los_Angeles",
+			elapsedDays:          2,
+			wantDates:            []time.Time{date(2026, time.March, 12), date(2026, time.March, 13)},
+			wantParticipantDates: []time.Time{date(2026, time.March, 11), date(2026, time.March, 12), date(2026, time.March, 13)},
+		},
+		{
+			name:                 "paused challenge resumed with a participant still on the day before",
+			participantTimeZone:  "America/Los_Angeles",
+			elapsedDays:          2,
+			pause:                true,
+			wantDates:            []time.Time{date(2026, time.March, 12), date(2026, time.March, 13)},
+			wantParticipantDates: []time.Time{date(2026, time.March, 11), date(2026, time.March, 12), date(2026, time.March, 13)},
+		},
+		{
+			name:        "paused challenge resumed the same day",
+			elapsedDays: 2,
+			pause:       true,
+			wantDates:   []time.Time{date(2026, time.March, 12), date(2026, time.March, 13)},
+		},
+		{
+			name:        "paused challenge resumed the next day",
+			elapsedDays: 2,
+			pause:       true,
+			resumeDays:  1,
+			wantDates:   []time.Time{date(2026, time.March, 13), date(2026, time.March, 15)},
+		},
+		{
+			name:        "started challenge without remaining days",
+			elapsedDays: 5,
+			wantErr:     "Challenge has no remaining days",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewFakeClock(now)
+			challengeService, taskService := newTestServices(t, clk)
+
+			challenge := createTestChallenge(t, challengeService, 5, "")
+
+			if tt.participantTimeZone != "" {
+				addTestParticipant(t, challengeService, challenge.Id, tt.participantTimeZone)
+			}
+
+			startTestChallenge(t, challengeService, challenge.Id)
+
+			clk.Advance(time.Duration(tt.elapsedDays) * 24 * time.Hour)
+
+			if tt.pause {
+				pauseTestChallenge(t, challengeService, challenge.Id)
+			}
+
+			task, err := taskService.CreateTask(context.Background(), &pb.CreateTaskRequest{
+				Title:       "Task",
+				ChallengeId: challenge.Id,
+				UserId:      testOwnerId,
+				WeekDays:    63,
+			})
+
+			if got := errorMessage(err); got != tt.wantErr {
+				t.Fatalf("error = %q, want %q", got, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if tt.pause {
+				if dates := taskStatusDates(t, taskService, task.Id, testOwnerId); len(dates) != 0 {
+					t.Errorf("paused challenge has task statuses on %v, want none until it is resumed", dates)
+				}
+
+				clk.Advance(time.Duration(tt.resumeDays) * 24 * time.Hour)
+
+				_, err := challengeService.ResumeChallenge(context.Background(), &pb.ResumeChallengeRequest{ChallengeId: challenge.Id, UserId: testOwnerId})
+				if err != nil {
+					t.Fatalf("resume challenge: %v", err)
+				}
+			}
+
+			if dates := taskStatusDates(t, taskService, task.Id, testOwnerId); !sameDates(dates, tt.wantDates) {
+				t.Errorf("owner task statuses on %v, want %v", dates, tt.wantDates)
+			}
+
+			if dates := taskStatusDates(t, taskService, task.Id, testParticipantId); !sameDates(dates, tt.wantParticipantDates) {
+				t.Errorf("participant task statuses on %v, want %v", dates, tt.wantParticipantDates)
+			}
+		})
+	}
+}
+
+// taskStatusDates returns the days the task is scheduled on for the user.
+func taskStatusDates(t *testing.T, taskService *TaskService, taskId, userId int64) []time.Time {
+	var taskAndStatuses []model.TaskAndStatus
+
+	if err := taskService.db.Where("task_id = ? AND user_id = ?", taskId, userId).Order("date").Find(&taskAndStatuses).Error; err != nil {
+		t.Fatalf("get task statuses: %v", err)
+	}
+
+	dates := make([]time.Time, 0, len(taskAndStatuses))
+	for _, taskAndStatus := range taskAndStatuses {
+		dates = append(dates, taskAndStatus.Date)
+	}
+
+	return dates
+}
+
+func sameDates(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestDeleteTaskKeepsPastDays(t *testing.T) {
+	// The challenge of an owner in Los Angeles starts on the 10th of March. The task is deleted at 03:00 UTC
+	// on the 12th, when it is still the 11th for the owner.
+	now := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		participantTimeZone  string
+		wantDates            []time.Time
+		wantParticipantDates []time.Time
+	}{
+		{
+			name:                 "participant in the time zone of the owner",
+			participantTimeZone:  "America/Los_Angeles",
+			wantDates:            []time.Time{date(2026, time.March, 10)},
+			wantParticipantDates: []time.Time{date(2026, time.March, 10)},
+		},
+		{
+			name:                 "participant ahead of the owner",
+			participantTimeZone:  "UTC",
+			wantDates:            []time.Time{date(2026, time.March, 10)},
+			wantParticipantDates: []time.Time{date(2026, time.March, 10), date(2026, time.March, 11)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewFakeClock(now)
+			challengeService, taskService := newTestServices(t, clk)
+
+			challenge := createTestChallenge(t, challengeService, 5, "America/Los_Angeles")
+			addTestParticipant(t, challengeService, challenge.Id, tt.participantTimeZone)
+
+			task, err := taskService.CreateTask(context.Background(), &pb.CreateTaskRequest{
+				Title:       "Task",
+				ChallengeId: challenge.Id,
+				UserId:      testOwnerId,
+				WeekDays:    int32(model.AllWeekDays),
+			})
+
+			if err != nil {
+				t.Fatalf("create task: %v", err)
+			}
+
+			startTestChallenge(t, challengeService, challenge.Id)
+
+			clk.Set(time.Date(2026, time.March, 12, 3, 0, 0, 0, time.UTC))
+
+			if _, err := taskService.DeleteTask(context.Background(), &pb.DeleteTaskRequest{Id: task.Id, ChallengeId: challenge.Id, UserId: testOwnerId}); err != nil {
+				t.Fatalf("delete task: %v", err)
+			}
+
+			if dates := taskStatusDates(t, taskService, task.Id, testOwnerId); !sameDates(dates, tt.wantDates) {
+				t.Errorf("owner task statuses on %v, want %v", dates, tt.wantDates)
+			}
+
+			if dates := taskStatusDates(t, taskService, task.Id, testParticipantId); !sameDates(dates, tt.wantParticipantDates) {
+				t.Errorf("participant task statuses on %v, want %v", dates, tt.wantParticipantDates)
+			}
+		})
+	}
+}
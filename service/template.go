@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	pb "ryg-task-service/gen_proto/task_service"
+	"ryg-task-service/model"
+)
+
+func (s *ChallengeService) SaveChallengeAsTemplate(ctx context.Context, req *pb.SaveChallengeAsTemplateRequest) (*pb.ChallengeTemplate, error) {
+	challenge, err := s.ValidateUserCanReadChallenge(req.ChallengeId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := getTasksByChallengeId(s.db, challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &model.ChallengeTemplate{
+		UserID:      req.UserId,
+		Title:       challenge.Title,
+		Description: challenge.Description,
+		Days:        challenge.Days,
+		Tasks:       make([]model.ChallengeTemplateTask, 0, len(tasks)),
+	}
+
+	taskIds := make([]int64, 0, len(tasks))
+	for _, task := range tasks {
+		taskIds = append(taskIds, task.ID)
+	}
+
+	checklists, err := getChecklistTitles(s.db, taskIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, task := range tasks {
+		template.Tasks = append(template.Tasks, model.ChallengeTemplateTask{
+			Title:       task.Title,
+			Description: task.Description,
+			WeekDays:    task.WeekDays,
+			TargetValue: task.TargetValue,
+			Unit:        task.Unit,
+			Tags:        task.Tags,
+			Recurrence:  task.Recurrence,
+			Window:      task.Window,
+			Checklist:   checklists[task.ID],
+			Required:    task.Required,
+			Weight:      task.Weight,
+		})
+	}
+
+	// Tasks are created together with the template through the association.
+	if err := s.db.WithContext(context.Background()).Create(&template).Error; err != nil {
+		return nil, err
+	}
+
+	return toPbChallengeTemplate(template), nil
+}
+
+func (s *ChallengeService) GetTemplatesByUserId(ctx context.Context, req *pb.GetTemplatesRequest) (*pb.ChallengeTemplateList, error) {
+	var templates []model.ChallengeTemplate
+
+	if err := s.db.WithContext(ctx).Preload("Tasks", orderByID).Where("user_id = ?", req.UserId).Order("id").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ChallengeTemplateList{
+		Templates: make([]*pb.ChallengeTemplate, 0),
+	}
+
+	for i := range templates {
+		resp.Templates = append(resp.Templates, toPbChallengeTemplate(&templates[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *ChallengeService) CreateChallengeFromTemplate(ctx context.Context, req *pb.CreateChallengeFromTemplateRequest) (*pb.Challenge, error) {
+	var template model.ChallengeTemplate
+
+	if err := s.db.WithContext(ctx).Preload("Tasks", orderByID).First(&template, "id = ? AND user_id = ?", req.TemplateId, req.UserId).Error; err != nil {
+		return nil, status.Error(404, "Template not found")
+	}
+
+	if err := s.validateChallengeDays(template.Days); err != nil {
+		return nil, err
+	}
+
+	if _, err := loadTimeZone(req.TimeZone); err != nil {
+		return nil, err
+	}
+
+	challenge := &model.Challenge{
+		Title:       template.Title,
+		Description: template.Description,
+		Status:      model.ChallengeStatusDraft,
+		Days:        template.Days,
+		Iteration:   1,
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := createChallengeOwnedByUser(tx, challenge, req.UserId, req.TimeZone); err != nil {
+			return err
+		}
+
+		if len(template.Tasks) == 0 {
+			return nil
+		}
+
+		tasks := make([]model.Task, 0, len(template.Tasks))
+		for i, templateTask := range template.Tasks {
+			tasks = append(tasks, model.Task{
+				Title:       templateTask.Title,
+				Description: templateTask.Description,
+				WeekDays:    templateTask.WeekDays,
+				ChallengeID: challenge.ID,
+				TargetValue: templateTask.TargetValue,
+				Unit:        templateTask.Unit,
+				Position:    int32(i + 1),
+				Tags:        templateTask.Tags,
+				Recurrence:  templateTask.Recurrence,
+				Window:      templateTask.Window,
+				Required:    templateTask.Required,
+				Weight:      templateTask.Weight,
+			})
+		}
+
+		if err := tx.WithContext(context.Background()).Create(&tasks).Error; err != nil {
+			return err
+		}
+
+		checklistItems := make([]model.ChecklistItem, 0)
+		for i, templateTask := range template.Tasks {
+			checklistItems = append(checklistItems, checklistItemsFromTitles(tasks[i].ID, templateTask.Checklist)...)
+		}
+
+		if len(checklistItems) == 0 {
+			return nil
+		}
+
+		return tx.WithContext(context.Background()).Create(&checklistItems).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toPbChallenge(challenge), nil
+}
+
+func toPbChallengeTemplate(template *model.ChallengeTemplate) *pb.ChallengeTemplate {
+	resp := &pb.ChallengeTemplate{
+		Id:          template.ID,
+		Title:       template.Title,
+		Description: template.Description,
+		Days:        template.Days,
+		Tasks:       make([]*pb.TemplateTask, 0, len(template.Tasks)),
+	}
+
+	for _, task := range template.Tasks {
+		resp.Tasks = append(resp.Tasks, &pb.TemplateTask{
+			Title:       task.Title,
+			Description: task.Description,
+			WeekDays:    int32(task.WeekDays),
+			TargetValue: task.TargetValue,
+			Unit:        task.Unit,
+			Tags:        task.Tags,
+			Recurrence:  toPbRecurrence(task.Recurrence),
+			Window:      toPbTimeWindow(task.Window),
+			Checklist:   task.Checklist,
+			Required:    *taskRequired(task.Required),
+			Weight:      task.Weight,
+		})
+	}
+
+	return resp
+}
+
+// orderByID keeps template tasks in the order they were saved in, which is the order of the challenge tasks.
+func orderByID(db *gorm.DB) *gorm.DB {
+	return db.Order("id")
+}
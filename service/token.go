@@ -3,6 +3,7 @@ package service
 import (
 	"fmt"
 	jwt "github.com/golang-jwt/jwt"
+	"ryg-task-service/clock"
 	"time"
 )
 
@@ -16,8 +17,8 @@ type ChallengeInvitationClaims struct {
 	jwt.StandardClaims
 }
 
-func GenerateJWT(userID, challengeID int64) (string, error) {
-	expirationTime := time.Now().Add(challengeInvitationExpirationTime)
+func GenerateJWT(clk clock.Clock, userID, challengeID int64) (string, error) {
+	expirationTime := clk.Now().Add(challengeInvitationExpirationTime)
 
 	claims := &ChallengeInvitationClaims{
 		UserID:      userID,
@@ -31,9 +32,11 @@ func GenerateJWT(userID, challengeID int64) (string, error) {
 	return token.SignedString(jwtKey)
 }
 
-func VerifyChallengeInvitationJWT(tokenStr string) (*ChallengeInvitationClaims, error) {
+func VerifyChallengeInvitationJWT(clk clock.Clock, tokenStr string) (*ChallengeInvitationClaims, error) {
 	claims := &ChallengeInvitationClaims{}
-	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
+	// Claims are validated below against the injected clock instead of the jwt package's own time source.
+	parser := &jwt.Parser{SkipClaimsValidation: true}
+	token, err := parser.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
 		return jwtKey, nil
 	})
 
@@ -43,5 +46,8 @@ func VerifyChallengeInvitationJWT(tokenStr string) (*ChallengeInvitationClaims,
 	if !token.Valid {
 		return nil, fmt.Errorf("invalid token")
 	}
+	if !claims.VerifyExpiresAt(clk.Now().Unix(), true) {
+		return nil, fmt.Errorf("token is expired")
+	}
 	return claims, nil
 }
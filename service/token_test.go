@@ -0,0 +1,53 @@
+package service
+
+import (
+	"ryg-task-service/clock"
+	"testing"
+	"time"
+)
+
+func TestVerifyChallengeInvitationJWT(t *testing.T) {
+	issuedAt := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		wantErr bool
+	}{
+		{name: "just issued", elapsed: 0},
+		{name: "a minute before expiry", elapsed: challengeInvitationExpirationTime - time.Minute},
+		{name: "at expiry", elapsed: challengeInvitationExpirationTime},
+		{name: "a second after expiry", elapsed: challengeInvitationExpirationTime + time.Second, wantErr: true},
+		{name: "days after expiry", elapsed: 3 * challengeInvitationExpirationTime, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewFakeClock(issuedAt)
+
+			token, err := GenerateJWT(clk, testParticipantId, 42)
+			if err != nil {
+				t.Fatalf("generate token: %v", err)
+			}
+
+			clk.Advance(tt.elapsed)
+
+			claims, err := VerifyChallengeInvitationJWT(clk, token)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected the token to be rejected")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("verify token: %v", err)
+			}
+
+			if claims.UserID != testParticipantId || claims.ChallengeID != 42 {
+				t.Errorf("claims = user %d, challenge %d, want user %d, challenge 42", claims.UserID, claims.ChallengeID, testParticipantId)
+			}
+		})
+	}
+}
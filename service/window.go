@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+	"google.golang.org/grpc/status"
+	pb "ryg-task-service/gen_proto/task_service"
+	"ryg-task-service/model"
+	"time"
+)
+
+const minutesPerDay = 24 * 60
+
+// timeWindowFromPb converts the window of a request. A window without both ends means the task
+// can be done at any time of the day.
+func timeWindowFromPb(window *pb.TimeWindow) (model.TimeWindow, error) {
+	if window == nil || (window.Start == "" && window.End == "") {
+		return model.TimeWindow{}, nil
+	}
+
+	start, err := parseMinuteOfDay(window.Start)
+	if err != nil {
+		return model.TimeWindow{}, err
+	}
+
+	end, err := parseMinuteOfDay(window.End)
+	if err != nil {
+		return model.TimeWindow{}, err
+	}
+
+	if start >= end {
+		return model.TimeWindow{}, status.Error(400, "Time window should end after it starts")
+	}
+
+	return model.TimeWindow{Start: &start, End: &end}, nil
+}
+
+// parseMinuteOfDay parses an HH:MM time of day, 24:00 standing for the end of the day.
+func parseMinuteOfDay(value string) (int32, error) {
+	var hours, minutes int32
+
+	if _, err := fmt.Sscanf(value, "%d:%d", &hours, &minutes); err != nil || len(value) != len("15:04") {
+		return 0, status.Error(400, "Invalid time of day")
+	}
+
+	minute := hours*60 + minutes
+
+	if hours < 0 || minutes < 0 || minutes >= 60 || minute > minutesPerDay {
+		return 0, status.Error(400, "Invalid time of day")
+	}
+
+	return minute, nil
+}
+
+func formatMinuteOfDay(minute int32) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+// isLateCompletion reports whether completing a task at the participant's local time now is past its window,
+// and rejects completing it before the window opens.
+func isLateCompletion(window model.TimeWindow, now time.Time) (bool, error) {
+	if !window.IsSet() {
+		return false, nil
+	}
+
+	if isBeforeWindow(window, now) {
+		return false, status.Error(400, fmt.Sprintf("Task cannot be completed before %s", formatMinuteOfDay(*window.Start)))
+	}
+
+	return minuteOfDay(now) >= *window.End, nil
+}
+
+// isBeforeWindow reports whether the window has not opened yet at the participant's local time now.
+func isBeforeWindow(window model.TimeWindow, now time.Time) bool {
+	return window.IsSet() && minuteOfDay(now) < *window.Start
+}
+
+func minuteOfDay(t time.Time) int32 {
+	return int32(t.Hour()*60 + t.Minute())
+}
+
+func toPbTimeWindow(window model.TimeWindow) *pb.TimeWindow {
+	if !window.IsSet() {
+		return nil
+	}
+
+	return &pb.TimeWindow{
+		Start: formatMinuteOfDay(*window.Start),
+		End:   formatMinuteOfDay(*window.End),
+	}
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"ryg-task-service/model"
+	"testing"
+	"time"
+)
+
+func TestIsLateCompletion(t *testing.T) {
+	start, end := int32(9*60), int32(12*60)
+	window := model.TimeWindow{Start: &start, End: &end}
+
+	tests := []struct {
+		name     string
+		window   model.TimeWindow
+		hour     int
+		minute   int
+		wantLate bool
+		wantErr  string
+	}{
+		{name: "no window", hour: 23, minute: 59},
+		{name: "before the window opens", window: window, hour: 8, minute: 59, wantErr: "Task cannot be completed before 09:00"},
+		{name: "when the window opens", window: window, hour: 9},
+		{name: "inside the window", window: window, hour: 11, minute: 59},
+		{name: "when the window closes", window: window, hour: 12, wantLate: true},
+		{name: "after the window", window: window, hour: 22, wantLate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2026, time.March, 12, tt.hour, tt.minute, 0, 0, time.UTC)
+
+			late, err := isLateCompletion(tt.window, now)
+
+			if got := errorMessage(err); got != tt.wantErr {
+				t.Errorf("error = %q, want %q", got, tt.wantErr)
+			}
+
+			if late != tt.wantLate {
+				t.Errorf("late = %t, want %t", late, tt.wantLate)
+			}
+		})
+	}
+}